@@ -0,0 +1,86 @@
+package supercsv
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Default boolean vocabulary, matched case-insensitively. The first word of
+// each list is what CSVWriter emits.
+var (
+	defaultTrueValues  = []string{"true", "t", "1", "yes", "y", "on"}
+	defaultFalseValues = []string{"false", "f", "0", "no", "n", "off"}
+)
+
+// parseBool looks value up in the field's vocabulary, ignoring case
+func parseBool(value string, field *fieldInfo) (bool, error) {
+	equal := func(word string) bool { return strings.EqualFold(word, value) }
+	switch {
+	case slices.ContainsFunc(field.trueValues, equal):
+		return true, nil
+	case slices.ContainsFunc(field.falseValues, equal):
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q (expected %s or %s): %w", value,
+		strings.Join(field.trueValues, "/"), strings.Join(field.falseValues, "/"), strconv.ErrSyntax)
+}
+
+// formatBool returns the canonical token for v
+func formatBool(v bool, field *fieldInfo) string {
+	if v {
+		return field.trueValues[0]
+	}
+	return field.falseValues[0]
+}
+
+// boolValues resolves a field's vocabulary from its tag, the iterator-level
+// option, and the defaults, in that order of precedence
+func boolValues(tag tagOptions, cfg *config) (trueValues, falseValues []string) {
+	trueValues, falseValues = defaultTrueValues, defaultFalseValues
+	if cfg.trueValues != nil {
+		trueValues = cfg.trueValues
+	}
+	if cfg.falseValues != nil {
+		falseValues = cfg.falseValues
+	}
+	if tag.trueValues != nil {
+		trueValues = tag.trueValues
+	}
+	if tag.falseValues != nil {
+		falseValues = tag.falseValues
+	}
+	return trueValues, falseValues
+}
+
+// checkBoolValues rejects a vocabulary that is empty or uses a word for both values
+func checkBoolValues(trueValues, falseValues []string) error {
+	if len(trueValues) == 0 || len(falseValues) == 0 {
+		return fmt.Errorf("boolean values need at least one true and one false word")
+	}
+	for _, word := range trueValues {
+		if slices.ContainsFunc(falseValues, func(other string) bool { return strings.EqualFold(word, other) }) {
+			return fmt.Errorf("%q is both a true and a false value", word)
+		}
+	}
+	return nil
+}
+
+// checkBoolTag validates the true= and false= options of a field
+func checkBoolTag(name string, fieldType reflect.Type, tag tagOptions) error {
+	if tag.trueValues == nil && tag.falseValues == nil {
+		return nil
+	}
+	for fieldType.Kind() == reflect.Ptr || isRepeatedField(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Bool {
+		return fmt.Errorf("field %s has true/false options but is not a bool, got %s", name, fieldType)
+	}
+	if err := checkBoolValues(boolValues(tag, &config{})); err != nil {
+		return fmt.Errorf("field %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package supercsv
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type Subscription struct {
+	Active  bool  `csv:"active"`
+	Renew   *bool `csv:"renew"`
+	Partner bool  `csv:"partner,true=ja|j,false=nein|n"`
+}
+
+func TestDefaultBoolValues(t *testing.T) {
+	csvData := `active,renew,partner
+Yes,ON,Ja
+n,off,nein
+T,1,j
+FALSE,0,N`
+
+	iterator, err := NewFromReader[Subscription](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	subs, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read subscriptions: %v", err)
+	}
+
+	expected := []bool{true, false, true, false}
+	for i, sub := range subs {
+		if sub.Active != expected[i] || sub.Renew == nil || *sub.Renew != expected[i] || sub.Partner != expected[i] {
+			t.Errorf("Row %d: expected all %v, got %+v (renew %v)", i+1, expected[i], sub, sub.Renew)
+		}
+	}
+}
+
+func TestInvalidBoolValue(t *testing.T) {
+	iterator, err := NewFromReader[Subscription](strings.NewReader("active,partner\nyes,yes"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.Next()
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Field != "Partner" || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected syntax error for Partner, got %v", err)
+	}
+}
+
+func TestWithBoolValues(t *testing.T) {
+	type Flag struct {
+		Enabled bool `csv:"enabled"`
+	}
+
+	iterator, err := New[Flag](FromReader(strings.NewReader("enabled\nsi\nno")), WithBoolValues([]string{"sí", "si"}, []string{"no"}))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	flags, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read flags: %v", err)
+	}
+	if !flags[0].Enabled || flags[1].Enabled {
+		t.Errorf("Unexpected flags: %+v, %+v", flags[0], flags[1])
+	}
+
+	if _, err := New[Flag](FromReader(strings.NewReader("enabled\nx")), WithBoolValues([]string{"x"}, []string{"X"})); err == nil || !strings.Contains(err.Error(), "both a true and a false value") {
+		t.Errorf("Expected overlapping vocabulary error, got %v", err)
+	}
+
+	type NotBool struct {
+		Name string `csv:"name,true=y"`
+	}
+	if _, err := NewFromReader[NotBool](strings.NewReader("name\nx")); err == nil || !strings.Contains(err.Error(), "not a bool") {
+		t.Errorf("Expected tag error, got %v", err)
+	}
+}
+
+func TestWriterBoolValues(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Subscription](&buf, WithBoolValues([]string{"Y"}, []string{"N"}))
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	renew := false
+	if err := writer.WriteAll([]*Subscription{{Active: true, Renew: &renew, Partner: true}}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	expected := "active,renew,partner\nY,N,ja\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,178 @@
+package supercsv
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression identifies the compression format of a CSV source
+type Compression int
+
+const (
+	// CompressionAuto detects compression from magic bytes or Content-Encoding (the default)
+	CompressionAuto Compression = iota
+	// CompressionNone reads the source as plain CSV
+	CompressionNone
+	// CompressionGzip reads gzip (.gz) data
+	CompressionGzip
+	// CompressionBzip2 reads bzip2 (.bz2) data
+	CompressionBzip2
+	// CompressionZstd reads zstd (.zst) data
+	CompressionZstd
+	// CompressionXz reads xz (.xz) data
+	CompressionXz
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionAuto:
+		return "auto"
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionBzip2:
+		return "bzip2"
+	case CompressionZstd:
+		return "zstd"
+	case CompressionXz:
+		return "xz"
+	}
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+var compressionMagic = []struct {
+	compression Compression
+	magic       []byte
+}{
+	{CompressionGzip, []byte{0x1f, 0x8b}},
+	{CompressionBzip2, []byte("BZh")},
+	{CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{CompressionXz, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+}
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = map[Compression]func(io.Reader) (io.ReadCloser, error){
+		CompressionGzip: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		CompressionBzip2: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(bzip2.NewReader(r)), nil
+		},
+		CompressionZstd: func(r io.Reader) (io.ReadCloser, error) {
+			d, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return d.IOReadCloser(), nil
+		},
+		CompressionXz: func(r io.Reader) (io.ReadCloser, error) {
+			x, err := xz.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return io.NopCloser(x), nil
+		},
+	}
+)
+
+// RegisterDecompressor replaces the decompressor used for a compression
+// format. gzip, bzip2, zstd and xz are built in.
+func RegisterDecompressor(c Compression, fn func(io.Reader) (io.ReadCloser, error)) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+
+	decompressors[c] = fn
+}
+
+// compressionFromEncoding maps an HTTP Content-Encoding header to a Compression
+func compressionFromEncoding(encoding string) Compression {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		return CompressionGzip
+	case "bzip2", "x-bzip2":
+		return CompressionBzip2
+	case "zstd":
+		return CompressionZstd
+	case "xz", "x-xz":
+		return CompressionXz
+	}
+	return CompressionAuto
+}
+
+// detectCompression peeks at the first bytes of the stream to identify its format
+func detectCompression(reader *bufio.Reader) Compression {
+	header, _ := reader.Peek(6)
+	for _, m := range compressionMagic {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression
+		}
+	}
+	return CompressionNone
+}
+
+// decompress wraps reader in the decompressor for the configured compression.
+// An explicit WithCompression takes precedence over hint (from Content-Encoding),
+// which takes precedence over magic byte detection. The returned closer closes
+// both the decompressor and the original closer.
+func decompress(reader io.Reader, closer io.Closer, cfg *config, hint Compression) (io.Reader, io.Closer, error) {
+	compression := cfg.compression
+	if compression == CompressionAuto {
+		compression = hint
+	}
+	if compression == CompressionAuto {
+		buffered := bufio.NewReader(reader)
+		reader = buffered
+		compression = detectCompression(buffered)
+	}
+	if compression == CompressionNone {
+		return reader, closer, nil
+	}
+
+	decompressorsMu.RLock()
+	fn, ok := decompressors[compression]
+	decompressorsMu.RUnlock()
+
+	if !ok {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, nil, fmt.Errorf("no decompressor registered for %s compression", compression)
+	}
+
+	decompressed, err := fn(reader)
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, nil, fmt.Errorf("failed to open %s stream: %w", compression, err)
+	}
+
+	return decompressed, multiCloser{decompressed, closer}, nil
+}
+
+// multiCloser closes each non-nil closer in order and returns the first error
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var firstErr error
+	for _, c := range m {
+		if c == nil {
+			continue
+		}
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
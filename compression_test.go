@@ -0,0 +1,162 @@
+package supercsv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const compressionCSV = "name,age,email\nJohn Doe,30,john@example.com\n"
+
+// bzip2 compressed compressionCSV
+var compressionBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x80, 0xe8, 0xf7, 0x10, 0x00, 0x00,
+	0x12, 0xdd, 0x80, 0x00, 0x10, 0x40, 0x05, 0x48, 0x00, 0x44, 0x10, 0x2a, 0xf7, 0xc0, 0x40, 0x20,
+	0x00, 0x22, 0x21, 0x90, 0xf5, 0x00, 0x1a, 0x68, 0x51, 0xa3, 0x20, 0x68, 0xd3, 0x23, 0x4d, 0xf3,
+	0xb9, 0x70, 0x7d, 0x02, 0x00, 0x73, 0x46, 0xaf, 0x93, 0x97, 0x9e, 0x37, 0x22, 0x79, 0xab, 0x26,
+	0xa2, 0x5e, 0x2c, 0xf5, 0x59, 0xb4, 0x3e, 0x2e, 0xe4, 0x8a, 0x70, 0xa1, 0x21, 0x01, 0xd1, 0xee,
+	0x20,
+}
+
+func gzipData(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(data)); err != nil {
+		t.Fatalf("Failed to compress data: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to compress data: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func readSinglePerson(t *testing.T, iterator *CSVIterator[Person], err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	people, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read people: %v", err)
+	}
+	if len(people) != 1 || people[0].Name != "John Doe" {
+		t.Errorf("Unexpected people: %v", people)
+	}
+}
+
+func TestCompression_GzipFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.csv.gz")
+	if err := os.WriteFile(path, gzipData(t, compressionCSV), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	iterator, err := NewFromFile[Person](path)
+	readSinglePerson(t, iterator, err)
+}
+
+func TestCompression_Bzip2Reader(t *testing.T) {
+	iterator, err := NewFromReader[Person](bytes.NewReader(compressionBzip2))
+	readSinglePerson(t, iterator, err)
+}
+
+func TestCompression_ContentEncoding(t *testing.T) {
+	body := gzipData(t, compressionCSV)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	iterator, err := New[Person](FromURLWithClient(client, server.URL))
+	readSinglePerson(t, iterator, err)
+}
+
+func TestCompression_Zstd(t *testing.T) {
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("Failed to create zstd writer: %v", err)
+	}
+	zw.Write([]byte(compressionCSV))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to compress data: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "people.csv.zst")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	iterator, err := NewFromFile[Person](path)
+	readSinglePerson(t, iterator, err)
+}
+
+func TestCompression_Xz(t *testing.T) {
+	var buf bytes.Buffer
+	xw, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("Failed to create xz writer: %v", err)
+	}
+	xw.Write([]byte(compressionCSV))
+	if err := xw.Close(); err != nil {
+		t.Fatalf("Failed to compress data: %v", err)
+	}
+
+	iterator, err := NewFromReader[Person](bytes.NewReader(buf.Bytes()))
+	readSinglePerson(t, iterator, err)
+
+	corrupt := append([]byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, compressionCSV...)
+	if _, err := NewFromReader[Person](bytes.NewReader(corrupt)); err == nil || !strings.Contains(err.Error(), "failed to open xz stream") {
+		t.Errorf("Expected xz error for corrupt data, got %v", err)
+	}
+}
+
+func TestCompression_RegisteredDecompressor(t *testing.T) {
+	zstdMagic := []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	builtin := decompressors[CompressionZstd]
+	t.Cleanup(func() { RegisterDecompressor(CompressionZstd, builtin) })
+
+	// A stand-in "zstd" format: the magic followed by plain text
+	RegisterDecompressor(CompressionZstd, func(r io.Reader) (io.ReadCloser, error) {
+		if _, err := io.ReadFull(r, make([]byte, len(zstdMagic))); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	})
+
+	data := append(append([]byte(nil), zstdMagic...), compressionCSV...)
+	iterator, err := NewFromReader[Person](bytes.NewReader(data))
+	readSinglePerson(t, iterator, err)
+}
+
+func TestCompression_MissingDecompressor(t *testing.T) {
+	_, err := New[Person](FromReader(strings.NewReader(compressionCSV)), WithCompression(Compression(42)))
+	if err == nil || !strings.Contains(err.Error(), "no decompressor registered for Compression(42) compression") {
+		t.Errorf("Expected missing decompressor error, got %v", err)
+	}
+}
+
+func TestCompression_Override(t *testing.T) {
+	iterator, err := New[Person](FromReader(bytes.NewReader(gzipData(t, compressionCSV))), WithCompression(CompressionGzip))
+	readSinglePerson(t, iterator, err)
+
+	_, err = New[Person](FromReader(strings.NewReader(compressionCSV)), WithCompression(CompressionGzip))
+	if err == nil || !strings.Contains(err.Error(), "failed to open gzip stream") {
+		t.Errorf("Expected gzip error for plain data, got %v", err)
+	}
+
+	iterator, err = New[Person](FromReader(strings.NewReader(compressionCSV)), WithCompression(CompressionNone))
+	readSinglePerson(t, iterator, err)
+}
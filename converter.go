@@ -0,0 +1,106 @@
+package supercsv
+
+import (
+	"encoding"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// CSVUnmarshaler is implemented by types that can decode themselves from a CSV cell.
+// It receives the raw cell value and the name of the column it came from.
+type CSVUnmarshaler interface {
+	UnmarshalCSV(value string, column string) error
+}
+
+var (
+	csvUnmarshalerType  = reflect.TypeOf((*CSVUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = make(map[reflect.Type]func(string) (reflect.Value, error))
+)
+
+// RegisterConverter registers a package-wide function that converts a CSV cell
+// into a value of type V. Registered converters are consulted before any
+// built-in conversion, so they can also override the handling of built-in types.
+// Registering a converter for the same type twice replaces the previous one.
+func RegisterConverter[V any](fn func(string) (V, error)) {
+	valueType := reflect.TypeOf((*V)(nil)).Elem()
+
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	converters[valueType] = func(s string) (reflect.Value, error) {
+		v, err := fn(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&v).Elem(), nil
+	}
+}
+
+func lookupConverter(fieldType reflect.Type) (func(string) (reflect.Value, error), bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	fn, ok := converters[fieldType]
+	return fn, ok
+}
+
+// unmarshalCustom decodes strValue using a registered converter, CSVUnmarshaler,
+// or encoding.TextUnmarshaler. It reports whether any of them handled the field.
+// time.Time is excluded from the interface checks so that it keeps its
+// multi-format parsing rather than the strict RFC3339 of its UnmarshalText,
+// and big.Float so that it keeps every digit rather than rounding to 64 bits.
+func unmarshalCustom(fieldValue reflect.Value, strValue string, fieldType reflect.Type, column string) (bool, error) {
+	if fn, ok := lookupConverter(fieldType); ok {
+		v, err := fn(strValue)
+		if err != nil {
+			return true, err
+		}
+		fieldValue.Set(v)
+		return true, nil
+	}
+
+	if fieldType == timeType || fieldType == bigFloatType || fieldType.Kind() == reflect.Ptr || !fieldValue.CanAddr() {
+		return false, nil
+	}
+
+	ptrType := reflect.PointerTo(fieldType)
+	switch {
+	case ptrType.Implements(csvUnmarshalerType):
+		return true, fieldValue.Addr().Interface().(CSVUnmarshaler).UnmarshalCSV(strValue, column)
+	case ptrType.Implements(textUnmarshalerType):
+		return true, fieldValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(strValue))
+	}
+
+	return false, nil
+}
+
+// marshalCustom formats fieldValue using encoding.TextMarshaler if implemented.
+// It reports whether the field was handled.
+func marshalCustom(fieldValue reflect.Value, fieldType reflect.Type) (string, bool, error) {
+	if fieldType == timeType || fieldType == bigFloatType || fieldType.Kind() == reflect.Ptr {
+		return "", false, nil
+	}
+
+	// Marshalers with a pointer receiver, such as *big.Int, need an addressable value
+	switch {
+	case fieldType.Implements(textMarshalerType):
+	case fieldValue.CanAddr() && reflect.PointerTo(fieldType).Implements(textMarshalerType):
+		fieldValue = fieldValue.Addr()
+	default:
+		return "", false, nil
+	}
+
+	text, err := fieldValue.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", true, err
+	}
+	return string(text), true, nil
+}
@@ -0,0 +1,129 @@
+package supercsv
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+type Status int
+
+const (
+	StatusUnknown Status = iota
+	StatusActive
+	StatusInactive
+)
+
+func (s *Status) UnmarshalCSV(value string, column string) error {
+	switch value {
+	case "active":
+		*s = StatusActive
+	case "inactive":
+		*s = StatusInactive
+	default:
+		return fmt.Errorf("unknown status %q in column %s", value, column)
+	}
+	return nil
+}
+
+type Host struct {
+	Name    string      `csv:"name,required"`
+	Addr    netip.Addr  `csv:"addr"`
+	Gateway *netip.Addr `csv:"gateway"`
+	Status  Status      `csv:"status"`
+	Budget  Money       `csv:"budget"`
+}
+
+func init() {
+	RegisterConverter(func(s string) (Money, error) {
+		var units, cents int64
+		var currency string
+		if _, err := fmt.Sscanf(s, "%d.%d %s", &units, &cents, &currency); err != nil {
+			return Money{}, fmt.Errorf("invalid money: %s", s)
+		}
+		return Money{Cents: units*100 + cents, Currency: currency}, nil
+	})
+}
+
+func TestCSVIterator_CustomTypes(t *testing.T) {
+	csvData := `name,addr,gateway,status,budget
+web,10.0.0.1,10.0.0.254,active,12.50 EUR
+db,::1,,inactive,0.99 USD`
+
+	iterator, err := NewFromReader[Host](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	hosts, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read hosts: %v", err)
+	}
+
+	if len(hosts) != 2 {
+		t.Fatalf("Expected 2 hosts, got %d", len(hosts))
+	}
+	if hosts[0].Addr != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("Expected addr 10.0.0.1, got %v", hosts[0].Addr)
+	}
+	if hosts[0].Gateway == nil || *hosts[0].Gateway != netip.MustParseAddr("10.0.0.254") {
+		t.Errorf("Expected gateway 10.0.0.254, got %v", hosts[0].Gateway)
+	}
+	if hosts[1].Gateway != nil {
+		t.Errorf("Expected nil gateway, got %v", hosts[1].Gateway)
+	}
+	if hosts[0].Status != StatusActive || hosts[1].Status != StatusInactive {
+		t.Errorf("Unexpected statuses: %v, %v", hosts[0].Status, hosts[1].Status)
+	}
+	if hosts[0].Budget != (Money{Cents: 1250, Currency: "EUR"}) {
+		t.Errorf("Unexpected budget: %+v", hosts[0].Budget)
+	}
+}
+
+func TestCSVIterator_CustomTypeError(t *testing.T) {
+	csvData := `name,status
+web,archived`
+
+	iterator, err := NewFromReader[Host](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.Next()
+	if err == nil {
+		t.Fatal("Expected error for unknown status")
+	}
+	if !strings.Contains(err.Error(), `unknown status "archived" in column status`) {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestCSVWriter_TextMarshaler(t *testing.T) {
+	type Route struct {
+		Name string     `csv:"name"`
+		Addr netip.Addr `csv:"addr"`
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Route](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Route{{Name: "lo", Addr: netip.MustParseAddr("127.0.0.1")}}); err != nil {
+		t.Fatalf("Failed to write routes: %v", err)
+	}
+
+	expected := "name,addr\nlo,127.0.0.1\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
@@ -35,14 +35,16 @@
 package supercsv
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -51,96 +53,236 @@ type CSVIterator[T any] struct {
 	reader     *csv.Reader
 	closer     io.Closer
 	headers    []string
-	fieldMap   map[string]int
+	fieldMap   map[string][]int
+	duplicates []DuplicateColumn
+	unmapped   []string
 	structType reflect.Type
 	fieldInfo  []fieldInfo
+	row        int
+	validate   func(*T) error
+
+	errorPolicy  ErrorPolicy
+	errors       []*ParseError
+	rejectWriter *csv.Writer
+	rejectHeader bool
+
+	closeOnDone bool
+	err         error
+
+	ctx       context.Context
+	closeOnce sync.Once
+	closeErr  error
 }
 
 type fieldInfo struct {
-	fieldIndex int
-	csvColumn  string
-	fieldType  reflect.Type
-	required   bool
+	fieldIndex  []int
+	name        string // Dotted Go field path, e.g. "Billing.Street"
+	csvColumn   string
+	columnIndex int
+	// columnIndexes lists every column collected into a slice field
+	columnIndexes []int
+	rest          bool
+	sep           string
+	fieldType     reflect.Type
+	required      bool
+	layout        string
+	defaultValue  string
+	nullValues    []string
+	timeFormats   []string
+	location      *time.Location
+	unixUnit      time.Duration
+	unit          string
+	number        *numberFormat
+	base          int
+	trueValues    []string
+	falseValues   []string
+	validators    []validator
 }
 
-// NewFromFile creates a CSV iterator from a file path
-func NewFromFile[T any](filepath string) (*CSVIterator[T], error) {
-	file, err := os.Open(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+// columns lists the positions a field spans: every column of a repeated
+// field, otherwise just its own
+func (f *fieldInfo) columns() []int {
+	if f.columnIndexes != nil {
+		return f.columnIndexes
 	}
+	return []int{f.columnIndex}
+}
 
-	return newIterator[T](file, file, ',')
+// isNull reports whether value is one of the field's null sentinels
+func (f *fieldInfo) isNull(value string) bool {
+	return slices.Contains(f.nullValues, value)
 }
 
-// NewFromFileWithDelimiter creates a CSV iterator from a file path with custom delimiter
-func NewFromFileWithDelimiter[T any](filepath string, delimiter rune) (*CSVIterator[T], error) {
-	file, err := os.Open(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+// nullToken is what the writer emits for a nil value
+func (f *fieldInfo) nullToken() string {
+	if len(f.nullValues) == 0 {
+		return ""
 	}
-
-	return newIterator[T](file, file, delimiter)
+	return f.nullValues[0]
 }
 
-// NewFromURL creates a CSV iterator from a URL
-func NewFromURL[T any](url string) (*CSVIterator[T], error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+// newFieldInfo combines a field's tag options with the defaults shared by
+// iterators and writers
+func newFieldInfo(field structField, cfg *config) fieldInfo {
+	tag := field.tag
+	info := fieldInfo{
+		fieldIndex:   field.index,
+		name:         field.name,
+		csvColumn:    tag.column,
+		columnIndex:  tag.index,
+		rest:         tag.rest,
+		sep:          tag.sep,
+		fieldType:    field.typ,
+		required:     tag.required,
+		layout:       tag.layout,
+		defaultValue: tag.defaultValue,
+		nullValues:   tag.nullValues,
+		location:     tag.location,
+		unixUnit:     tag.unixUnit,
+		unit:         tag.unit,
+		number:       newNumberFormat(tag, cfg.locale, field.typ),
+		base:         tag.base,
 	}
+	info.trueValues, info.falseValues = boolValues(tag, cfg)
 
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	if info.nullValues == nil {
+		info.nullValues = cfg.nullValues
 	}
 
-	return newIterator[T](resp.Body, resp.Body, ',')
+	return info
 }
 
-// NewFromURLWithDelimiter creates a CSV iterator from a URL with custom delimiter
-func NewFromURLWithDelimiter[T any](url string, delimiter rune) (*CSVIterator[T], error) {
-	resp, err := http.Get(url)
+// setReadDefaults fills in the iterator-level time settings a field does not
+// override. Writers keep times in their own location.
+func (f *fieldInfo) setReadDefaults(cfg *config) {
+	switch {
+	case f.layout != "":
+		f.timeFormats = []string{f.layout}
+	case cfg.timeFormats != nil:
+		f.timeFormats = cfg.timeFormats
+	default:
+		f.timeFormats = timeFormats(cfg.dateOrder)
+	}
+	if f.location == nil {
+		f.location = cfg.location
+	}
+}
+
+// New creates a CSV iterator from any source, configured with functional options
+func New[T any](src Source, opts ...Option) (*CSVIterator[T], error) {
+	cfg := newConfig(opts)
+
+	reader, closer, err := src.open(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+		return nil, err
+	}
+	reader = decodeText(reader, cfg.encoding)
+
+	if cfg.ctx == nil || closer == nil {
+		return newIterator[T](reader, closer, cfg)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	// Tear down the source if the context ends while reading the header
+	stop := context.AfterFunc(cfg.ctx, func() { closer.Close() })
+	defer stop()
+
+	it, err := newIterator[T](reader, closer, cfg)
+	if ctxErr := cfg.ctx.Err(); ctxErr != nil {
+		if it != nil {
+			it.Close()
+		}
+		return nil, ctxErr
 	}
+	return it, err
+}
+
+// NewFromFile creates a CSV iterator from a file path
+func NewFromFile[T any](filepath string) (*CSVIterator[T], error) {
+	return New[T](FromFile(filepath))
+}
 
-	return newIterator[T](resp.Body, resp.Body, delimiter)
+// NewFromFileWithDelimiter creates a CSV iterator from a file path with custom delimiter
+func NewFromFileWithDelimiter[T any](filepath string, delimiter rune) (*CSVIterator[T], error) {
+	return New[T](FromFile(filepath), WithDelimiter(delimiter))
+}
+
+// NewFromURL creates a CSV iterator from a URL
+func NewFromURL[T any](url string) (*CSVIterator[T], error) {
+	return New[T](FromURL(url))
+}
+
+// NewFromURLWithDelimiter creates a CSV iterator from a URL with custom delimiter
+func NewFromURLWithDelimiter[T any](url string, delimiter rune) (*CSVIterator[T], error) {
+	return New[T](FromURL(url), WithDelimiter(delimiter))
+}
+
+// NewFromURLWithClient creates a CSV iterator from a URL using a custom HTTP client.
+// Cancelling ctx aborts the request and any later reads from the response body.
+func NewFromURLWithClient[T any](ctx context.Context, client *http.Client, url string, opts ...Option) (*CSVIterator[T], error) {
+	return New[T](FromURLWithClient(client, url), append([]Option{WithContext(ctx)}, opts...)...)
 }
 
 // NewFromReader creates a CSV iterator from an io.Reader
 func NewFromReader[T any](reader io.Reader) (*CSVIterator[T], error) {
-	return newIterator[T](reader, nil, ',')
+	return New[T](FromReader(reader))
 }
 
 // NewFromReaderWithDelimiter creates a CSV iterator from an io.Reader with custom delimiter
 func NewFromReaderWithDelimiter[T any](reader io.Reader, delimiter rune) (*CSVIterator[T], error) {
-	return newIterator[T](reader, nil, delimiter)
+	return New[T](FromReader(reader), WithDelimiter(delimiter))
 }
 
-func newIterator[T any](reader io.Reader, closer io.Closer, delimiter rune) (*CSVIterator[T], error) {
+func newIterator[T any](reader io.Reader, closer io.Closer, cfg *config) (*CSVIterator[T], error) {
 	csvReader := csv.NewReader(reader)
-	csvReader.Comma = delimiter           // Set custom delimiter
-	csvReader.FieldsPerRecord = -1        // Allow variable number of fields
+	csvReader.Comma = cfg.delimiter
+	csvReader.Comment = cfg.comment
+	csvReader.LazyQuotes = cfg.lazyQuotes
+	csvReader.TrimLeadingSpace = cfg.trimLeadingSpace
+	csvReader.FieldsPerRecord = -1 // Preamble rows may have any shape
+
+	// Discard records before the header row
+	for i := 0; i < cfg.headerRow; i++ {
+		if _, err := csvReader.Read(); err != nil {
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, fmt.Errorf("failed to skip rows before header: %w", err)
+		}
+	}
+
+	csvReader.FieldsPerRecord = cfg.fieldsPerRecord
 
 	// Read headers
-	headers, err := csvReader.Read()
-	if err != nil {
-		if closer != nil {
-			closer.Close()
+	var headers []string
+	if !cfg.noHeader {
+		var err error
+		headers, err = csvReader.Read()
+		if err != nil {
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, fmt.Errorf("failed to read headers: %w", err)
+		}
+	}
+
+	// Skip leading data rows
+	for i := 0; i < cfg.skipRows; i++ {
+		if _, err := csvReader.Read(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, fmt.Errorf("failed to skip rows: %w", err)
 		}
-		return nil, fmt.Errorf("failed to read headers: %w", err)
 	}
 
 	// Create field mapping
-	fieldMap := make(map[string]int)
+	fieldMap := make(map[string][]int)
 	for i, header := range headers {
-		fieldMap[strings.TrimSpace(header)] = i
+		key := cfg.normalizeHeader(strings.TrimSpace(header))
+		fieldMap[key] = append(fieldMap[key], i)
 	}
 
 	// Analyze struct type and build field info
@@ -157,7 +299,7 @@ func newIterator[T any](reader io.Reader, closer io.Closer, delimiter rune) (*CS
 		return nil, fmt.Errorf("type parameter must be a struct, got %s", structType.Kind())
 	}
 
-	fieldInfo, err := buildFieldInfo(structType, fieldMap)
+	fieldInfo, err := buildFieldInfo(structType, headers, fieldMap, cfg)
 	if err != nil {
 		if closer != nil {
 			closer.Close()
@@ -165,114 +307,560 @@ func newIterator[T any](reader io.Reader, closer io.Closer, delimiter rune) (*CS
 		return nil, err
 	}
 
-	return &CSVIterator[T]{
-		reader:     csvReader,
-		closer:     closer,
-		headers:    headers,
-		fieldMap:   fieldMap,
-		structType: structType,
-		fieldInfo:  fieldInfo,
-	}, nil
+	var validate func(*T) error
+	if cfg.rowValidator != nil {
+		var ok bool
+		if validate, ok = cfg.rowValidator.(func(*T) error); !ok {
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, fmt.Errorf("WithValidator function is %T, want func(*%s) error", cfg.rowValidator, reflect.TypeOf(zero))
+		}
+	}
+
+	unmapped := findUnmapped(headers, fieldInfo)
+	if cfg.strictColumns {
+		if err := checkStrictColumns(unmapped, cfg); err != nil {
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, err
+		}
+	}
+
+	it := &CSVIterator[T]{
+		reader:      csvReader,
+		closer:      closer,
+		headers:     headers,
+		fieldMap:    fieldMap,
+		duplicates:  findDuplicates(headers, fieldMap, fieldInfo, cfg),
+		unmapped:    unmapped,
+		structType:  structType,
+		fieldInfo:   fieldInfo,
+		row:         cfg.skipRows,
+		errorPolicy: cfg.errorPolicy,
+		closeOnDone: cfg.autoClose,
+		ctx:         cfg.ctx,
+		validate:    validate,
+	}
+
+	if cfg.rejects != nil {
+		it.rejectWriter = csv.NewWriter(cfg.rejects)
+		it.rejectWriter.Comma = cfg.delimiter
+	}
+
+	return it, nil
+}
+
+// findUnmapped lists the headers whose columns no field reads
+func findUnmapped(headers []string, fields []fieldInfo) []string {
+	mapped := make(map[int]bool)
+	for _, field := range fields {
+		mapped[field.columnIndex] = true
+		for _, columnIndex := range field.columnIndexes {
+			mapped[columnIndex] = true
+		}
+	}
+
+	var unmapped []string
+	for i, header := range headers {
+		if !mapped[i] {
+			unmapped = append(unmapped, strings.TrimSpace(header))
+		}
+	}
+	return unmapped
+}
+
+// checkStrictColumns fails if any unmapped column is not on the allowlist
+func checkStrictColumns(unmapped []string, cfg *config) error {
+	allowed := make(map[string]bool)
+	for _, name := range cfg.allowedColumns {
+		allowed[cfg.normalizeHeader(name)] = true
+	}
+
+	var unexpected []string
+	for _, header := range unmapped {
+		if !allowed[cfg.normalizeHeader(header)] {
+			unexpected = append(unexpected, fmt.Sprintf("'%s'", header))
+		}
+	}
+
+	if len(unexpected) > 0 {
+		return fmt.Errorf("CSV columns not mapped to any field: %s", strings.Join(unexpected, ", "))
+	}
+	return nil
 }
 
-func buildFieldInfo(structType reflect.Type, fieldMap map[string]int) ([]fieldInfo, error) {
+func buildFieldInfo(structType reflect.Type, headers []string, fieldMap map[string][]int, cfg *config) ([]fieldInfo, error) {
 	var fields []fieldInfo
+	restField := -1
 
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
-		
-		// Skip unexported fields
-		if !field.IsExported() {
-			continue
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	structFields, err := walkFields(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range structFields {
+		tag := field.tag
+		info := newFieldInfo(field, cfg)
+		info.setReadDefaults(cfg)
+
+		if info.validators, err = compileRules(field.name, field.typ, tag.rules); err != nil {
+			return nil, err
 		}
 
-		csvTag := field.Tag.Get("csv")
-		if csvTag == "" {
-			return nil, fmt.Errorf("field %s missing required 'csv' annotation", field.Name)
+		if err := checkDefault(&info); err != nil {
+			return nil, err
 		}
 
-		// Parse csv tag (format: "column_name" or "column_name,required")
-		parts := strings.Split(csvTag, ",")
-		csvColumn := strings.TrimSpace(parts[0])
-		required := false
+		if tag.rest {
+			if err := checkRestField(field, restField >= 0); err != nil {
+				return nil, err
+			}
+			restField = len(fields)
+			fields = append(fields, info)
+			continue
+		}
 
-		for _, part := range parts[1:] {
-			if strings.TrimSpace(part) == "required" {
-				required = true
+		if tag.index >= 0 {
+			// Positional fields are bound even when the header is short,
+			// since rows may still carry the column
+			if headers != nil && tag.index >= len(headers) && tag.required {
+				return nil, fmt.Errorf("required CSV column #%d not found for field %s", tag.index, field.name)
+			}
+			if isRepeatedField(field.typ) && tag.sep == "" {
+				info.columnIndexes = []int{tag.index}
 			}
+			if tag.index < len(headers) {
+				info.csvColumn = strings.TrimSpace(headers[tag.index])
+			} else if info.csvColumn == "" {
+				info.csvColumn = fmt.Sprintf("#%d", tag.index)
+			}
+			fields = append(fields, info)
+			continue
 		}
 
-		// Check if column exists in CSV
-		_, exists := fieldMap[csvColumn]
+		// Check if column exists in CSV, under its name or any alias.
+		// Patterns match every header they fit, in header order.
+		var columnIndexes []int
+		exists := false
+		for _, name := range append([]string{tag.column}, tag.aliases...) {
+			if isColumnPattern(name) {
+				columnIndexes = matchColumns(name, headers, cfg)
+				exists = len(columnIndexes) > 0
+			} else {
+				columnIndexes, exists = fieldMap[cfg.normalizeHeader(name)]
+			}
+			if exists {
+				break
+			}
+		}
 		if !exists {
-			if required {
-				return nil, fmt.Errorf("required CSV column '%s' not found for field %s", csvColumn, field.Name)
+			if tag.defaultValue != "" {
+				// Keep the field so every row gets its default, even when
+				// it is required
+				info.columnIndex = -1
+				fields = append(fields, info)
+				continue
+			}
+			if tag.required {
+				if headers == nil {
+					return nil, fmt.Errorf("required CSV column '%s' for field %s cannot be matched without a header; use an index tag", tag.column, field.name)
+				}
+				if len(tag.aliases) > 0 {
+					return nil, fmt.Errorf("required CSV column '%s' (aliases: %s) not found for field %s", tag.column, strings.Join(tag.aliases, ", "), field.name)
+				}
+				return nil, fmt.Errorf("required CSV column '%s' not found for field %s", tag.column, field.name)
 			}
 			continue // Skip optional missing columns
 		}
 
-		fields = append(fields, fieldInfo{
-			fieldIndex: i,
-			csvColumn:  csvColumn,
-			fieldType:  field.Type,
-			required:   required,
-		})
+		// Slice and array fields collect every column sharing the name; other
+		// fields pick one according to the duplicate policy
+		columnIndex := columnIndexes[0]
+		switch {
+		case isRepeatedField(field.typ) && tag.sep == "":
+			if field.typ.Kind() == reflect.Array && len(columnIndexes) > field.typ.Len() {
+				return nil, fmt.Errorf("field %s holds %d values but %d columns match '%s'",
+					field.name, field.typ.Len(), len(columnIndexes), tag.column)
+			}
+			info.columnIndexes = columnIndexes
+		case len(columnIndexes) > 1:
+			switch cfg.duplicatePolicy {
+			case DuplicateFirst:
+			case DuplicateLast:
+				columnIndex = columnIndexes[len(columnIndexes)-1]
+			default:
+				return nil, fmt.Errorf("duplicate CSV column '%s' at positions %s for field %s; use WithDuplicatePolicy or a slice field",
+					strings.TrimSpace(headers[columnIndex]), formatPositions(columnIndexes), field.name)
+			}
+		}
+
+		info.columnIndex = columnIndex
+		info.csvColumn = strings.TrimSpace(headers[columnIndex])
+		fields = append(fields, info)
+	}
+
+	// The rest field claims every column no other field reads
+	if restField >= 0 {
+		claimed := make(map[int]bool)
+		for _, field := range fields {
+			claimed[field.columnIndex] = true
+			for _, columnIndex := range field.columnIndexes {
+				claimed[columnIndex] = true
+			}
+		}
+		// Map keys are header names, so unclaimed columns sharing a name
+		// follow the duplicate policy rather than overwriting each other
+		byName := make(map[string][]int)
+		var names []string
+		for i, header := range headers {
+			if claimed[i] {
+				continue
+			}
+			name := strings.TrimSpace(header)
+			if _, ok := byName[name]; !ok {
+				names = append(names, name)
+			}
+			byName[name] = append(byName[name], i)
+		}
+
+		fields[restField].columnIndexes = []int{}
+		for _, name := range names {
+			columnIndexes := byName[name]
+			columnIndex := columnIndexes[0]
+			if len(columnIndexes) > 1 {
+				switch cfg.duplicatePolicy {
+				case DuplicateFirst:
+				case DuplicateLast:
+					columnIndex = columnIndexes[len(columnIndexes)-1]
+				default:
+					return nil, fmt.Errorf("duplicate CSV column '%s' at positions %s for rest field %s; use WithDuplicatePolicy",
+						name, formatPositions(columnIndexes), fields[restField].name)
+				}
+			}
+			fields[restField].columnIndexes = append(fields[restField].columnIndexes, columnIndex)
+		}
+		slices.Sort(fields[restField].columnIndexes)
 	}
 
 	return fields, nil
 }
 
-// Next reads and parses the next CSV row into the struct type
+// Next reads and parses the next CSV row into the struct type.
+// Rows that fail to parse are handled according to the iterator's ErrorPolicy.
+// If the iterator was created with WithContext, Next behaves like NextContext with that context.
 func (it *CSVIterator[T]) Next() (*T, error) {
+	if it.ctx != nil {
+		return it.NextContext(it.ctx)
+	}
+	return it.next()
+}
+
+// NextContext is like Next but returns ctx.Err() once ctx is done.
+// If the read is blocked on a source that can be closed (a file or HTTP body),
+// the source is closed to interrupt it and the iterator cannot be used further.
+func (it *CSVIterator[T]) NextContext(ctx context.Context) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { it.Close() })
+	defer stop()
+
+	item, err := it.next()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return item, err
+}
+
+func (it *CSVIterator[T]) next() (*T, error) {
+	for {
+		item, record, err := it.readRow()
+		if err == nil || err == io.EOF {
+			return item, err
+		}
+
+		perr, ok := err.(*ParseError)
+		if !ok {
+			return nil, err
+		}
+		it.errors = append(it.errors, perr)
+
+		if rejectErr := it.reject(record); rejectErr != nil {
+			return nil, rejectErr
+		}
+
+		if !isRecoverable(perr) {
+			return nil, perr
+		}
+		if !it.errorPolicy.tolerates(len(it.errors)) {
+			if !it.errorPolicy.skip {
+				return nil, perr
+			}
+			return nil, fmt.Errorf("%w: %w", ErrTooManyErrors, perr)
+		}
+	}
+}
+
+// Errors returns every row error encountered so far, including skipped rows
+func (it *CSVIterator[T]) Errors() []*ParseError {
+	return it.errors
+}
+
+// reject writes an offending record to the reject sink, preceded by the header on first use
+func (it *CSVIterator[T]) reject(record []string) error {
+	if it.rejectWriter == nil || record == nil {
+		return nil
+	}
+
+	if !it.rejectHeader && it.headers != nil {
+		if err := it.rejectWriter.Write(it.headers); err != nil {
+			return fmt.Errorf("failed to write rejected record: %w", err)
+		}
+		it.rejectHeader = true
+	}
+
+	if err := it.rejectWriter.Write(record); err != nil {
+		return fmt.Errorf("failed to write rejected record: %w", err)
+	}
+	it.rejectWriter.Flush()
+	if err := it.rejectWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write rejected record: %w", err)
+	}
+	return nil
+}
+
+// readRow reads the next record and decodes it, returning the raw record alongside
+func (it *CSVIterator[T]) readRow() (*T, []string, error) {
 	record, err := it.reader.Read()
+	if err == io.EOF {
+		return nil, nil, err
+	}
+	it.row++
 	if err != nil {
-		return nil, err // This includes io.EOF
+		return nil, record, newRecordError(it.row, err)
+	}
+
+	item, err := it.decodeRecord(record)
+	if err == nil {
+		err = it.validateRow(item)
+	}
+	return item, record, err
+}
+
+// RowValidator is implemented by row types that check rules spanning several
+// fields. Next calls Validate on each decoded row.
+type RowValidator interface {
+	Validate() error
+}
+
+// validateRow runs the row's Validate method, then the WithValidator function
+func (it *CSVIterator[T]) validateRow(item *T) error {
+	var err error
+	if v, ok := any(item).(RowValidator); ok {
+		err = v.Validate()
+	} else if v, ok := any(*item).(RowValidator); ok {
+		// T is itself a pointer type
+		err = v.Validate()
+	}
+	if err == nil && it.validate != nil {
+		err = it.validate(item)
 	}
+	if err == nil {
+		return nil
+	}
+
+	line, _ := it.reader.FieldPos(0)
+	return &ParseError{Row: it.row, Line: line, Err: &RowValidationError{Err: err}}
+}
 
+// decodeRecord parses a single record into the struct type
+func (it *CSVIterator[T]) decodeRecord(record []string) (*T, error) {
 	// Create new instance
 	var result T
 	resultValue := reflect.ValueOf(&result).Elem()
 
-	// Handle pointer types
-	if it.structType.Kind() == reflect.Ptr {
-		newStruct := reflect.New(it.structType.Elem())
+	// Handle pointer types: T = *S decodes into a new S
+	if resultValue.Kind() == reflect.Ptr {
+		newStruct := reflect.New(it.structType)
 		resultValue.Set(newStruct)
 		resultValue = newStruct.Elem()
 	}
 
 	// Parse each field
 	for _, field := range it.fieldInfo {
-		columnIndex := it.fieldMap[field.csvColumn]
-		
-		// Check if we have enough columns
-		if columnIndex >= len(record) {
-			if field.required {
-				return nil, fmt.Errorf("missing required column '%s' in CSV row", field.csvColumn)
+		if field.rest {
+			rest := make(map[string]string, len(field.columnIndexes))
+			for _, columnIndex := range field.columnIndexes {
+				if columnIndex < len(record) {
+					rest[strings.TrimSpace(it.headers[columnIndex])] = strings.TrimSpace(record[columnIndex])
+				}
 			}
+			fieldByIndexAlloc(resultValue, field.fieldIndex).Set(reflect.ValueOf(rest))
 			continue
 		}
 
-		value := strings.TrimSpace(record[columnIndex])
-		
-		// Skip empty values for non-required fields
-		if value == "" && !field.required {
+		if field.columnIndexes != nil {
+			fieldValue := fieldByIndexAlloc(resultValue, field.fieldIndex)
+			if err := it.decodeRepeated(fieldValue, record, &field); err != nil {
+				return nil, err
+			}
+			if err := field.validateValue(fieldValue, cellsText(record, field.columnIndexes)); err != nil {
+				return nil, it.fieldError(&field, record, field.columnIndexes[0], err)
+			}
 			continue
 		}
 
-		if err := setFieldValue(resultValue.Field(field.fieldIndex), value, field.fieldType); err != nil {
-			return nil, fmt.Errorf("failed to parse field %s (column %s): %w", 
-				it.structType.Field(field.fieldIndex).Name, field.csvColumn, err)
+		columnIndex := field.columnIndex
+
+		// Check if we have enough columns. A negative index means the column
+		// is absent from the header and only the default applies.
+		var value string
+		switch {
+		case columnIndex >= 0 && columnIndex < len(record):
+			value = strings.TrimSpace(record[columnIndex])
+		case field.required && field.defaultValue == "":
+			line, _ := it.reader.FieldPos(0)
+			return nil, &ParseError{
+				Row:    it.row,
+				Line:   line,
+				Header: field.csvColumn,
+				Field:  field.name,
+				Err:    fmt.Errorf("missing required column '%s' in CSV row", field.csvColumn),
+			}
+		}
+
+		// Null sentinels are read as empty, except that a required field
+		// without a default rejects them
+		if field.isNull(value) {
+			if field.required && field.defaultValue == "" {
+				return nil, it.fieldError(&field, record, columnIndex, fmt.Errorf("null value in required column '%s'", field.csvColumn))
+			}
+			value = ""
+		}
+
+		// Empty values take the default; without one they are skipped for
+		// non-required fields
+		if value == "" {
+			if field.defaultValue != "" {
+				value = field.defaultValue
+			} else if !field.required {
+				continue
+			}
+		}
+
+		fieldValue := fieldByIndexAlloc(resultValue, field.fieldIndex)
+		if err := setFieldValue(fieldValue, value, field.fieldType, &field); err != nil {
+			return nil, it.fieldError(&field, record, columnIndex, err)
+		}
+		if err := field.validateCell(value); err != nil {
+			return nil, it.fieldError(&field, record, columnIndex, err)
+		}
+		if err := field.validateValue(fieldValue, value); err != nil {
+			return nil, it.fieldError(&field, record, columnIndex, err)
 		}
 	}
 
 	return &result, nil
 }
 
-func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.Type) error {
+// decodeRepeated fills a slice field with one element per non-empty column.
+// Array elements keep the position of their column, empty cells leave zeros.
+func (it *CSVIterator[T]) decodeRepeated(fieldValue reflect.Value, record []string, field *fieldInfo) error {
+	elemType := field.fieldType.Elem()
+
+	if field.fieldType.Kind() == reflect.Array {
+		for i, columnIndex := range field.columnIndexes {
+			if columnIndex >= len(record) {
+				continue
+			}
+			value := strings.TrimSpace(record[columnIndex])
+			if value == "" || field.isNull(value) {
+				continue
+			}
+			if err := setFieldValue(fieldValue.Index(i), value, elemType, field); err != nil {
+				return it.fieldError(field, record, columnIndex, err)
+			}
+			if err := field.validateText(value); err != nil {
+				return it.fieldError(field, record, columnIndex, err)
+			}
+		}
+		return nil
+	}
+
+	slice := reflect.MakeSlice(field.fieldType, 0, len(field.columnIndexes))
+
+	for _, columnIndex := range field.columnIndexes {
+		if columnIndex >= len(record) {
+			continue
+		}
+		value := strings.TrimSpace(record[columnIndex])
+		if value == "" || field.isNull(value) {
+			continue
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := setFieldValue(elem, value, elemType, field); err != nil {
+			return it.fieldError(field, record, columnIndex, err)
+		}
+		if err := field.validateText(value); err != nil {
+			return it.fieldError(field, record, columnIndex, err)
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	if slice.Len() > 0 {
+		fieldValue.Set(slice)
+	}
+	return nil
+}
+
+func (it *CSVIterator[T]) fieldError(field *fieldInfo, record []string, columnIndex int, err error) *ParseError {
+	// A default applied to an absent column has no position of its own
+	if columnIndex < 0 || columnIndex >= len(record) {
+		line, _ := it.reader.FieldPos(0)
+		return &ParseError{
+			Row:    it.row,
+			Line:   line,
+			Header: field.csvColumn,
+			Field:  field.name,
+			Value:  field.defaultValue,
+			Err:    err,
+		}
+	}
+
+	line, column := it.reader.FieldPos(columnIndex)
+	header := field.csvColumn
+	if columnIndex < len(it.headers) {
+		header = strings.TrimSpace(it.headers[columnIndex])
+	}
+	return &ParseError{
+		Row:    it.row,
+		Line:   line,
+		Column: column,
+		Header: header,
+		Field:  field.name,
+		Value:  record[columnIndex],
+		Err:    err,
+	}
+}
+
+func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.Type, field *fieldInfo) error {
 	if !fieldValue.CanSet() {
 		return fmt.Errorf("field cannot be set")
 	}
 
+	// Registered converters and unmarshaler interfaces take precedence
+	if handled, err := unmarshalCustom(fieldValue, strValue, fieldType, field.csvColumn); handled {
+		return err
+	}
+
 	switch fieldType.Kind() {
 	case reflect.String:
 		fieldValue.SetString(strValue)
@@ -281,9 +869,21 @@ func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.
 		if strValue == "" {
 			return nil // Leave zero value
 		}
-		intVal, err := strconv.ParseInt(strValue, 10, 64)
+		if fieldType == durationType {
+			d, err := parseDuration(strValue, field)
+			if err != nil {
+				return err
+			}
+			fieldValue.SetInt(int64(d))
+			return nil
+		}
+		number, err := field.number.normalize(strValue)
+		if err != nil {
+			return err
+		}
+		intVal, err := strconv.ParseInt(trimBasePrefix(number, field.base), field.base, fieldType.Bits())
 		if err != nil {
-			return fmt.Errorf("invalid integer: %s", strValue)
+			return numberError("integer", strValue, fieldType, err)
 		}
 		fieldValue.SetInt(intVal)
 
@@ -291,9 +891,13 @@ func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.
 		if strValue == "" {
 			return nil // Leave zero value
 		}
-		uintVal, err := strconv.ParseUint(strValue, 10, 64)
+		number, err := field.number.normalize(strValue)
+		if err != nil {
+			return err
+		}
+		uintVal, err := strconv.ParseUint(trimBasePrefix(number, field.base), field.base, fieldType.Bits())
 		if err != nil {
-			return fmt.Errorf("invalid unsigned integer: %s", strValue)
+			return numberError("unsigned integer", strValue, fieldType, err)
 		}
 		fieldValue.SetUint(uintVal)
 
@@ -301,9 +905,13 @@ func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.
 		if strValue == "" {
 			return nil // Leave zero value
 		}
-		floatVal, err := strconv.ParseFloat(strValue, 64)
+		number, err := field.number.normalize(strValue)
+		if err != nil {
+			return err
+		}
+		floatVal, err := strconv.ParseFloat(number, fieldType.Bits())
 		if err != nil {
-			return fmt.Errorf("invalid float: %s", strValue)
+			return numberError("float", strValue, fieldType, err)
 		}
 		fieldValue.SetFloat(floatVal)
 
@@ -311,9 +919,9 @@ func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.
 		if strValue == "" {
 			return nil // Leave zero value
 		}
-		boolVal, err := strconv.ParseBool(strValue)
+		boolVal, err := parseBool(strValue, field)
 		if err != nil {
-			return fmt.Errorf("invalid boolean: %s", strValue)
+			return err
 		}
 		fieldValue.SetBool(boolVal)
 
@@ -323,45 +931,36 @@ func setFieldValue(fieldValue reflect.Value, strValue string, fieldType reflect.
 			if strValue == "" {
 				return nil // Leave zero value
 			}
-			
-			// Try common time formats in order of preference
-			formats := []string{
-				time.RFC3339,     // "2006-01-02T15:04:05Z07:00"
-				time.RFC3339Nano, // "2006-01-02T15:04:05.999999999Z07:00"
-				"2006-01-02 15:04:05", // Common SQL datetime format
-				"2006-01-02",     // Date only
-				"15:04:05",       // Time only
-				"01/02/2006",     // US date format
-				"01/02/2006 15:04:05", // US datetime format
-				"02/01/2006",     // European date format
-				"02/01/2006 15:04:05", // European datetime format
-			}
-			
-			var timeVal time.Time
-			var err error
-			for _, format := range formats {
-				// Use UTC for formats without explicit timezone to ensure cross-platform consistency
-				if format == time.RFC3339 || format == time.RFC3339Nano {
-					timeVal, err = time.Parse(format, strValue)
-				} else {
-					timeVal, err = time.ParseInLocation(format, strValue, time.UTC)
-				}
-				if err == nil {
-					fieldValue.Set(reflect.ValueOf(timeVal))
-					return nil
-				}
+
+			timeVal, err := parseTime(strValue, field)
+			if err != nil {
+				return err
 			}
-			return fmt.Errorf("invalid time format: %s (supported formats: RFC3339, YYYY-MM-DD, YYYY-MM-DD HH:MM:SS, etc.)", strValue)
+			fieldValue.Set(reflect.ValueOf(timeVal))
+			return nil
+		}
+		if fieldType == bigFloatType {
+			if strValue == "" {
+				return nil // Leave zero value
+			}
+			return setBigFloat(fieldValue, strValue)
 		}
 		return fmt.Errorf("unsupported struct type: %s", fieldType)
 
+	case reflect.Slice, reflect.Array:
+		// In-cell lists need a separator; repeated columns are handled by decodeRepeated
+		if field.sep == "" {
+			return fmt.Errorf("unsupported field type: %s without a sep option", fieldType.Kind())
+		}
+		return setList(fieldValue, strValue, fieldType, field)
+
 	case reflect.Ptr:
 		if strValue == "" {
 			return nil // Leave nil
 		}
 		// Create new instance of the pointed-to type
 		newVal := reflect.New(fieldType.Elem())
-		if err := setFieldValue(newVal.Elem(), strValue, fieldType.Elem()); err != nil {
+		if err := setFieldValue(newVal.Elem(), strValue, fieldType.Elem(), field); err != nil {
 			return err
 		}
 		fieldValue.Set(newVal)
@@ -389,7 +988,7 @@ func (it *CSVIterator[T]) ForEach(fn func(*T, error) bool) {
 // ToSlice reads all remaining CSV rows into a slice
 func (it *CSVIterator[T]) ToSlice() ([]*T, error) {
 	var results []*T
-	
+
 	for {
 		item, err := it.Next()
 		if err == io.EOF {
@@ -400,7 +999,7 @@ func (it *CSVIterator[T]) ToSlice() ([]*T, error) {
 		}
 		results = append(results, item)
 	}
-	
+
 	return results, nil
 }
 
@@ -409,10 +1008,24 @@ func (it *CSVIterator[T]) Headers() []string {
 	return it.headers
 }
 
-// Close closes the underlying reader if it implements io.Closer
+// UnmappedColumns returns the header names of columns not read by any field,
+// in header order. It is populated whether or not WithStrictColumns is used.
+func (it *CSVIterator[T]) UnmappedColumns() []string {
+	return it.unmapped
+}
+
+// Duplicates reports header names that appear more than once and how each was bound
+func (it *CSVIterator[T]) Duplicates() []DuplicateColumn {
+	return it.duplicates
+}
+
+// Close closes the underlying reader if it implements io.Closer.
+// It is safe to call Close more than once.
 func (it *CSVIterator[T]) Close() error {
-	if it.closer != nil {
-		return it.closer.Close()
-	}
-	return nil
-}
\ No newline at end of file
+	it.closeOnce.Do(func() {
+		if it.closer != nil {
+			it.closeErr = it.closer.Close()
+		}
+	})
+	return it.closeErr
+}
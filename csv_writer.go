@@ -0,0 +1,408 @@
+package supercsv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CSVWriter provides generic CSV writing with struct annotations.
+// It uses the same csv tags as CSVIterator so that data can be round-tripped.
+type CSVWriter[T any] struct {
+	writer     *csv.Writer
+	closer     io.Closer
+	headers    []string
+	structType reflect.Type
+	fieldInfo  []fieldInfo
+
+	noHeader      bool
+	headerWritten bool
+	rest          *fieldInfo
+	restColumns   map[string]int
+}
+
+// NewWriterToFile creates a CSV writer that writes to a newly created file.
+// Options that only affect reading are ignored.
+func NewWriterToFile[T any](filepath string, opts ...Option) (*CSVWriter[T], error) {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return newWriter[T](file, file, newConfig(opts))
+}
+
+// NewWriterToWriter creates a CSV writer from an io.Writer.
+// Options that only affect reading are ignored.
+func NewWriterToWriter[T any](writer io.Writer, opts ...Option) (*CSVWriter[T], error) {
+	return newWriter[T](writer, nil, newConfig(opts))
+}
+
+func newWriter[T any](writer io.Writer, closer io.Closer, cfg *config) (*CSVWriter[T], error) {
+	var zero T
+	structType := reflect.TypeOf(zero)
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("type parameter must be a struct, got %s", structType.Kind())
+	}
+
+	fields, err := buildWriterFieldInfo(structType, cfg)
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, err
+	}
+
+	var rest *fieldInfo
+	width := 0
+	for i, field := range fields {
+		if field.rest {
+			rest = &fields[i]
+			continue
+		}
+		if field.collectsColumns() {
+			continue
+		}
+		for _, columnIndex := range field.columns() {
+			width = max(width, columnIndex+1)
+		}
+	}
+
+	headers := make([]string, width)
+	for _, field := range fields {
+		if field.rest || field.collectsColumns() {
+			continue
+		}
+		for i, columnIndex := range field.columns() {
+			headers[columnIndex] = patternColumn(field.csvColumn, i)
+		}
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = cfg.delimiter
+
+	return &CSVWriter[T]{
+		writer:     csvWriter,
+		closer:     closer,
+		headers:    headers,
+		structType: structType,
+		fieldInfo:  fields,
+		noHeader:   cfg.noHeader,
+		rest:       rest,
+	}, nil
+}
+
+// writeHeader writes the header row before the first record. Slices that
+// collect repeated columns get one column per element of the first item,
+// named by replacing the * of their pattern with the position. Keys of the
+// rest field in the first item follow as extra columns, in sorted order.
+func (w *CSVWriter[T]) writeHeader(itemValue reflect.Value) error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	for i := range w.fieldInfo {
+		field := &w.fieldInfo[i]
+		if !field.collectsColumns() {
+			continue
+		}
+		field.columnIndexes = []int{}
+		count := 0
+		if fieldValue := fieldByIndex(itemValue, field.fieldIndex); fieldValue.IsValid() {
+			count = fieldValue.Len()
+		}
+		for n := range count {
+			field.columnIndexes = append(field.columnIndexes, len(w.headers))
+			w.headers = append(w.headers, patternColumn(field.csvColumn, n))
+		}
+	}
+
+	if w.rest != nil {
+		w.restColumns = make(map[string]int)
+		if restValue := fieldByIndex(itemValue, w.rest.fieldIndex); restValue.IsValid() {
+			rest := restValue.Interface().(map[string]string)
+			for _, key := range slices.Sorted(maps.Keys(rest)) {
+				w.restColumns[key] = len(w.headers)
+				w.headers = append(w.headers, key)
+			}
+		}
+	}
+
+	if w.noHeader {
+		return nil
+	}
+	if err := w.writer.Write(w.headers); err != nil {
+		return fmt.Errorf("failed to write headers: %w", err)
+	}
+	return nil
+}
+
+// buildWriterFieldInfo collects every tagged field and assigns its output column.
+// Fields with an index tag are written at that position and the remaining
+// fields fill the free positions in declaration order.
+func buildWriterFieldInfo(structType reflect.Type, cfg *config) ([]fieldInfo, error) {
+	var fields []fieldInfo
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	structFields, err := walkFields(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range structFields {
+		if field.tag.rest {
+			if err := checkRestField(field, slices.ContainsFunc(fields, func(f fieldInfo) bool { return f.rest })); err != nil {
+				return nil, err
+			}
+		}
+
+		fields = append(fields, newFieldInfo(field, cfg))
+	}
+
+	// Arrays without a separator span one column per element
+	widths := make([]int, len(fields))
+	for i, field := range fields {
+		widths[i] = 1
+		if field.fieldType.Kind() == reflect.Array && field.sep == "" && isRepeatedField(field.fieldType) {
+			widths[i] = field.fieldType.Len()
+			fields[i].columnIndexes = []int{}
+		}
+	}
+
+	taken := make(map[int]string)
+	for i, field := range fields {
+		if field.columnIndex < 0 || field.rest {
+			continue
+		}
+		if field.collectsColumns() {
+			return nil, fmt.Errorf("field %s collects repeated columns and cannot be written at a fixed index", field.name)
+		}
+		for columnIndex := field.columnIndex; columnIndex < field.columnIndex+widths[i]; columnIndex++ {
+			if other, ok := taken[columnIndex]; ok {
+				return nil, fmt.Errorf("fields %s and %s both bound to column #%d", other, field.name, columnIndex)
+			}
+			taken[columnIndex] = field.name
+			if fields[i].columnIndexes != nil {
+				fields[i].columnIndexes = append(fields[i].columnIndexes, columnIndex)
+			}
+		}
+	}
+
+	next := 0
+	for i := range fields {
+		if fields[i].columnIndex >= 0 || fields[i].rest || fields[i].collectsColumns() {
+			continue
+		}
+		for n := 0; n < widths[i]; n++ {
+			for taken[next] != "" {
+				next++
+			}
+			if n == 0 {
+				fields[i].columnIndex = next
+			}
+			if fields[i].columnIndexes != nil {
+				fields[i].columnIndexes = append(fields[i].columnIndexes, next)
+			}
+			taken[next] = fields[i].name
+		}
+	}
+
+	return fields, nil
+}
+
+// Write formats a single struct as a CSV row
+func (w *CSVWriter[T]) Write(item *T) error {
+	if item == nil {
+		return fmt.Errorf("cannot write nil item")
+	}
+
+	itemValue := reflect.ValueOf(item).Elem()
+
+	// Handle pointer types
+	if itemValue.Kind() == reflect.Ptr {
+		if itemValue.IsNil() {
+			return fmt.Errorf("cannot write nil item")
+		}
+		itemValue = itemValue.Elem()
+	}
+
+	if err := w.writeHeader(itemValue); err != nil {
+		return err
+	}
+
+	record := make([]string, len(w.headers))
+	for _, field := range w.fieldInfo {
+		fieldValue := fieldByIndex(itemValue, field.fieldIndex)
+		if !fieldValue.IsValid() {
+			// Fields of a nil nested struct are written as null
+			if !field.rest {
+				for _, columnIndex := range field.columns() {
+					record[columnIndex] = field.nullToken()
+				}
+			}
+			continue
+		}
+
+		if field.rest {
+			if err := w.fillRest(record, fieldValue); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.columnIndexes != nil {
+			if fieldValue.Len() > len(field.columnIndexes) {
+				return fmt.Errorf("field %s has %d values but the first written row's header has %d columns for it",
+					field.name, fieldValue.Len(), len(field.columnIndexes))
+			}
+			for i, columnIndex := range field.columnIndexes[:fieldValue.Len()] {
+				value, err := formatFieldValue(fieldValue.Index(i), field.fieldType.Elem(), &field)
+				if err != nil {
+					return fmt.Errorf("failed to format field %s (column %s): %w",
+						field.name, w.headers[columnIndex], err)
+				}
+				record[columnIndex] = value
+			}
+			continue
+		}
+
+		value, err := formatFieldValue(fieldValue, field.fieldType, &field)
+		if err != nil {
+			return fmt.Errorf("failed to format field %s (column %s): %w",
+				field.name, field.csvColumn, err)
+		}
+		record[field.columnIndex] = value
+	}
+
+	return w.writer.Write(record)
+}
+
+// fillRest places the rest field's values in their extra columns
+func (w *CSVWriter[T]) fillRest(record []string, restValue reflect.Value) error {
+	for key, value := range restValue.Interface().(map[string]string) {
+		columnIndex, ok := w.restColumns[key]
+		if !ok {
+			return fmt.Errorf("rest column '%s' was not in the first written row's header", key)
+		}
+		record[columnIndex] = value
+	}
+	return nil
+}
+
+// WriteAll writes all items and flushes the underlying writer
+func (w *CSVWriter[T]) WriteAll(items []*T) error {
+	for _, item := range items {
+		if err := w.Write(item); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying writer.
+// If nothing has been written yet, the header row is written first.
+func (w *CSVWriter[T]) Flush() error {
+	if err := w.writeHeader(reflect.Value{}); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Headers returns the CSV column headers emitted by the writer.
+// Columns of repeated-column slices and a rest field are only known after the
+// first Write.
+func (w *CSVWriter[T]) Headers() []string {
+	return w.headers
+}
+
+// Close flushes buffered data and closes the underlying writer if it implements io.Closer
+func (w *CSVWriter[T]) Close() error {
+	err := w.Flush()
+	if w.closer != nil {
+		if closeErr := w.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func formatFieldValue(fieldValue reflect.Value, fieldType reflect.Type, field *fieldInfo) (string, error) {
+	// Types implementing encoding.TextMarshaler format themselves
+	if text, handled, err := marshalCustom(fieldValue, fieldType); handled {
+		return text, err
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return fieldValue.String(), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fieldType == durationType {
+			return formatDuration(time.Duration(fieldValue.Int()), field), nil
+		}
+		return field.number.format(strconv.FormatInt(fieldValue.Int(), formatBase(field.base))), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.number.format(strconv.FormatUint(fieldValue.Uint(), formatBase(field.base))), nil
+
+	case reflect.Float32, reflect.Float64:
+		shift := 0
+		if field.number != nil && field.number.percent {
+			shift = 2
+		}
+		return field.number.format(formatFloat(fieldValue.Float(), fieldType.Bits(), shift)), nil
+
+	case reflect.Bool:
+		return formatBool(fieldValue.Bool(), field), nil
+
+	case reflect.Struct:
+		// Handle time.Time specifically
+		if fieldType == reflect.TypeOf(time.Time{}) {
+			timeVal := fieldValue.Interface().(time.Time)
+			if timeVal.IsZero() {
+				return "", nil // Zero time round-trips as an empty cell
+			}
+			return formatTime(timeVal, field), nil
+		}
+		if fieldType == bigFloatType && fieldValue.CanAddr() {
+			// Plain decimal notation rather than MarshalText's exponent form
+			return fieldValue.Addr().Interface().(*big.Float).Text('f', -1), nil
+		}
+		return "", fmt.Errorf("unsupported struct type: %s", fieldType)
+
+	case reflect.Slice, reflect.Array:
+		if field.sep == "" {
+			return "", fmt.Errorf("unsupported field type: %s without a sep option", fieldType.Kind())
+		}
+		return formatList(fieldValue, fieldType, field)
+
+	case reflect.Ptr:
+		if fieldValue.IsNil() {
+			return field.nullToken(), nil // Nil pointers are written as null
+		}
+		return formatFieldValue(fieldValue.Elem(), fieldType.Elem(), field)
+
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", fieldType.Kind())
+	}
+}
@@ -0,0 +1,215 @@
+package supercsv
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVWriter_Basic(t *testing.T) {
+	salary := 50000.5
+	people := []*Person{
+		{Name: "John Doe", Age: 30, Email: "john@example.com", Salary: &salary, Active: true},
+		{Name: "Jane Smith", Age: 25, Email: "jane@example.com", Active: false},
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Person](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	if err := writer.WriteAll(people); err != nil {
+		t.Fatalf("Failed to write people: %v", err)
+	}
+
+	expected := `name,age,email,salary,active
+John Doe,30,john@example.com,50000.5,true
+Jane Smith,25,jane@example.com,,false
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	end := time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)
+	events := []*Event{
+		{Name: "Conference", StartDate: time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC), EndTime: &end, Duration: 480},
+		{Name: "Workshop, part 1", Duration: 240},
+	}
+
+	path := filepath.Join(t.TempDir(), "events.csv")
+	writer, err := NewWriterToFile[Event](path)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	for _, event := range events {
+		if err := writer.Write(event); err != nil {
+			t.Fatalf("Failed to write event: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	iterator, err := NewFromFile[Event](path)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	decoded, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+
+	if len(decoded) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(decoded))
+	}
+	if !decoded[0].StartDate.Equal(events[0].StartDate) {
+		t.Errorf("Expected start date %v, got %v", events[0].StartDate, decoded[0].StartDate)
+	}
+	if decoded[0].EndTime == nil || !decoded[0].EndTime.Equal(end) {
+		t.Errorf("Expected end time %v, got %v", end, decoded[0].EndTime)
+	}
+	if decoded[1].Name != "Workshop, part 1" {
+		t.Errorf("Expected name 'Workshop, part 1', got '%s'", decoded[1].Name)
+	}
+	if !decoded[1].StartDate.IsZero() || decoded[1].EndTime != nil {
+		t.Errorf("Expected zero start date and nil end time, got %v and %v", decoded[1].StartDate, decoded[1].EndTime)
+	}
+}
+
+func TestCSVWriter_MissingAnnotation(t *testing.T) {
+	type BadStruct struct {
+		Name string // Missing csv annotation
+	}
+
+	_, err := NewWriterToWriter[BadStruct](&bytes.Buffer{})
+	if err == nil {
+		t.Fatal("Expected error for missing csv annotation")
+	}
+	if !strings.Contains(err.Error(), "missing required 'csv' annotation") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func ExampleNewWriterToWriter() {
+	writer, err := NewWriterToWriter[Product](os.Stdout)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer writer.Close()
+
+	writer.Write(&Product{ID: 1, Name: "Laptop", Price: 999.99, InStock: true})
+	writer.Write(&Product{ID: 2, Name: "Mouse", Price: 29.99, Description: "Wireless mouse"})
+	// Output:
+	// id,product_name,price,in_stock,description
+	// 1,Laptop,999.99,true,
+	// 2,Mouse,29.99,false,Wireless mouse
+}
+
+func TestCSVWriter_IndexTags(t *testing.T) {
+	type Row struct {
+		Name  string  `csv:"name"`
+		Code  string  `csv:"#3"`
+		Score float64 `csv:"score,index=0"`
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Row](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Row{{Name: "a", Code: "X1", Score: 1.5}}); err != nil {
+		t.Fatalf("Failed to write rows: %v", err)
+	}
+
+	expected := "score,name,,\n1.5,a,,X1\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVWriter_NoHeader(t *testing.T) {
+	balance := 996.5
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[BankTransaction](&buf, WithNoHeader(), WithDelimiter(';'))
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	err = writer.WriteAll([]*BankTransaction{{
+		Date:        time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Description: "Coffee",
+		Amount:      -3.5,
+		Balance:     &balance,
+	}})
+	if err != nil {
+		t.Fatalf("Failed to write transactions: %v", err)
+	}
+
+	expected := "2024-03-01T00:00:00Z;Coffee;-3.5;996.5\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVWriter_DuplicateIndex(t *testing.T) {
+	type Row struct {
+		A string `csv:"#1"`
+		B string `csv:"b,index=1"`
+	}
+
+	_, err := NewWriterToWriter[Row](&bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "both bound to column #1") {
+		t.Errorf("Expected duplicate index error, got %v", err)
+	}
+}
+
+func TestCSVWriter_RestColumns(t *testing.T) {
+	items := []*CatalogItem{
+		{SKU: "A-1", Price: 9.99, Attributes: map[string]string{"size": "M", "color": "red"}},
+		{SKU: "B-2", Price: 19.99, Attributes: map[string]string{"size": "L"}},
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[CatalogItem](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll(items); err != nil {
+		t.Fatalf("Failed to write items: %v", err)
+	}
+
+	expected := "sku,price,color,size\nA-1,9.99,red,M\nB-2,19.99,,L\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	err = writer.Write(&CatalogItem{SKU: "C-3", Attributes: map[string]string{"weight": "1kg"}})
+	if err == nil || !strings.Contains(err.Error(), "rest column 'weight'") {
+		t.Errorf("Expected unknown rest column error, got %v", err)
+	}
+}
+
+func TestCSVWriter_HeaderOnlyOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Product](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	expected := "id,product_name,price,in_stock,description\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,95 @@
+package supercsv
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type LineItem struct {
+	SKU      string    `csv:"sku,required"`
+	Currency string    `csv:"currency,default=USD"`
+	Quantity int       `csv:"quantity,default=1"`
+	Discount *float64  `csv:"discount,default=0.5"`
+	Shipped  time.Time `csv:"shipped,default=2024-01-01"`
+}
+
+func TestDefaultValues(t *testing.T) {
+	csvData := `sku,currency,quantity
+A-1,EUR,3
+B-2,,
+C-3,GBP`
+
+	iterator, err := NewFromReader[LineItem](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	items, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read items: %v", err)
+	}
+
+	expected := []struct {
+		currency string
+		quantity int
+	}{{"EUR", 3}, {"USD", 1}, {"GBP", 1}}
+
+	for i, item := range items {
+		if item.Currency != expected[i].currency || item.Quantity != expected[i].quantity {
+			t.Errorf("Row %d: expected %s x%d, got %s x%d", i+1, expected[i].currency, expected[i].quantity, item.Currency, item.Quantity)
+		}
+		// discount and shipped are absent from the header entirely
+		if item.Discount == nil || *item.Discount != 0.5 {
+			t.Errorf("Row %d: expected default discount 0.5, got %v", i+1, item.Discount)
+		}
+		if !item.Shipped.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Row %d: expected default shipped date, got %v", i+1, item.Shipped)
+		}
+	}
+
+	// Each row gets its own pointer
+	if items[0].Discount == items[1].Discount {
+		t.Error("Expected defaults not to share pointers between rows")
+	}
+
+	if len(iterator.UnmappedColumns()) != 0 {
+		t.Errorf("Expected no unmapped columns, got %v", iterator.UnmappedColumns())
+	}
+}
+
+func TestRequiredDefault(t *testing.T) {
+	type Price struct {
+		SKU      string `csv:"sku,required"`
+		Currency string `csv:"currency,required,default=USD"`
+	}
+
+	// The default satisfies required both for an absent column and for
+	// empty cells
+	for _, csvData := range []string{"sku\nA-1", "sku,currency\nA-1,"} {
+		iterator, err := NewFromReader[Price](strings.NewReader(csvData))
+		if err != nil {
+			t.Fatalf("Failed to create iterator: %v", err)
+		}
+		price, err := iterator.Next()
+		if err != nil {
+			t.Fatalf("Failed to read price: %v", err)
+		}
+		if price.Currency != "USD" {
+			t.Errorf("Expected default currency USD, got %q", price.Currency)
+		}
+		iterator.Close()
+	}
+}
+
+func TestInvalidDefault(t *testing.T) {
+	type BadDefault struct {
+		Quantity int `csv:"quantity,default=many"`
+	}
+
+	_, err := NewFromReader[BadDefault](strings.NewReader("quantity\n1"))
+	if err == nil || !strings.Contains(err.Error(), `field Quantity: invalid default "many"`) {
+		t.Errorf("Expected invalid default error, got %v", err)
+	}
+}
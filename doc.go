@@ -11,7 +11,9 @@
 //   - CSV column mapping via struct tags
 //   - Support for required and optional fields
 //   - Multiple data sources (files, URLs, readers)
+//   - Writing structs back to CSV using the same tags
 //   - Memory-efficient row-by-row processing
+//   - Range-over-func iteration with All and Rows
 //   - Type-safe parsing with comprehensive error handling
 //   - Support for various Go types: string, int, uint, float, bool, time.Time, and pointers
 //
@@ -56,41 +58,308 @@
 //
 //	`csv:"column_name"`          // Maps to CSV column, optional field
 //	`csv:"column_name,required"` // Maps to CSV column, required field
+//	`csv:"currency,default=USD"` // Used when the cell is empty or the column absent
+//	`csv:"#3"`                   // Maps to the fourth column by position
+//	`csv:"amount,index=3"`       // Position for reading, name for the written header
+//	`csv:",rest"`                // map[string]string receiving all other columns
+//	`csv:"billing_,prefix"`      // Nested struct whose columns start with billing_
+//	`csv:",inline"`              // Nested struct whose columns keep their names
+//	`csv:"tags,sep=|"`           // Slice or array split from one cell
+//	`csv:"score_*"`              // Slice or array collecting every matching column
+//	`csv:"age,min=0,max=150"`    // Rejects values outside the range
+//
+// Defaults are parsed when the iterator is created, so an invalid default is
+// reported up front. A default also satisfies required, so a required field
+// with a default never fails for a missing or empty value.
 //
 // All struct fields that should be parsed MUST have a csv tag. Fields without
 // csv tags are ignored and will cause an error.
 //
+// # Header Matching
+//
+// Header names are matched to csv tags exactly (after trimming spaces). To
+// accept several upstream layouts with one struct, list aliases in the tag
+// and optionally normalize names before matching:
+//
+//	type Contact struct {
+//	    Email string `csv:"email,required,aliases=e-mail|email_address"`
+//	}
+//
+//	// "Email", "E-Mail", "Email Address", and "E-mail Address" now all match
+//	iterator, err := supercsv.New[Contact](src, supercsv.WithNormalizedHeaders())
+//
+// WithCaseInsensitiveHeaders only folds case, WithNormalizedHeaders also
+// ignores spaces, underscores, and hyphens, and WithHeaderNormalizer
+// accepts a custom function applied to both header and tag names.
+//
+// # Duplicate Headers
+//
+// If a field is bound to a header name that appears more than once, creating
+// the iterator fails rather than silently reading one of the columns. Choose a
+// column explicitly with WithDuplicatePolicy(DuplicateFirst) or
+// WithDuplicatePolicy(DuplicateLast), or declare a slice field to collect
+// every column with that name:
+//
+//	type Record struct {
+//	    IDs []string `csv:"id"` // id,name,id → both id columns
+//	}
+//
+// Duplicates reports every repeated header and which columns were read.
+//
+// # List Fields
+//
+// Slice and array fields either split a single cell on the separator given by
+// the sep option, or collect repeated columns. A column name containing * is a
+// pattern matching any run of characters, and the columns it matches are read
+// in header order:
+//
+//	type Scorecard struct {
+//	    Tags   []string `csv:"tags,sep=|"` // a|b|c
+//	    Scores []int    `csv:"score_*"`    // score_1, score_2, ...
+//	    Rounds [3]int   `csv:"round_*"`    // Element i from the i-th round_ column
+//	}
+//
+// Empty cells are skipped in slices and leave zero elements in arrays. When
+// writing, sep fields are joined into one cell and arrays without sep span one
+// column per element, with * replaced by the 1-based position. Slices without
+// sep are written after the other fields, with as many columns as the first
+// written item has elements; later items may have fewer but not more.
+//
+// # Null Values
+//
+// WithNullValues lists sentinels such as "NULL" or `\N` that are read as empty
+// cells: pointers stay nil, other fields keep their zero value or default, and
+// required fields fail. A null= tag option replaces the list for one field,
+// and an empty null= disables it:
+//
+//	type Row struct {
+//	    Qty     *int   `csv:"qty"`
+//	    Country string `csv:"country,null=-"` // "NA" is a country code here
+//	}
+//
+//	iterator, err := supercsv.New[Row](src, supercsv.WithNullValues("NULL", `\N`, "NA"))
+//
+// CSVWriter writes the first sentinel for nil pointers.
+//
+// # Number Formats
+//
+// Numeric fields accept locale-specific separators, currency symbols, and
+// percentages through tag options:
+//
+//	type Entry struct {
+//	    Amount float64 `csv:"amount,locale=en,currency=$"` // "$1,234.56"
+//	    Price  float64 `csv:"price,locale=de"`             // "1.234,56"
+//	    Total  float64 `csv:"total,thousands=,,decimal=."` // "1,234.56"
+//	    Rate   float64 `csv:"rate,percent"`                // "45%" → 0.45
+//	}
+//
+// A comma option value is written as "thousands=," followed by the next
+// option. The currency option ignores currency symbols when reading; its value,
+// if any, is written before the number. WithLocale sets the locale of numeric
+// fields without number options of their own. CSVWriter formats numbers the
+// same way, so files round-trip, except that integers only get thousands
+// separators from a locale= or thousands= option on the field; years and IDs
+// are written as they are.
+//
+// Numbers are parsed with the bit size of their field, so 300 in an int8 field
+// fails with an *OverflowError rather than wrapping around. The base tag option
+// reads integers in another base; base=16 also accepts a 0x prefix, and base=0
+// detects 0x, 0o and 0b prefixes:
+//
+//	type Device struct {
+//	    ID   uint32 `csv:"id,base=16"` // "1f" or "0x1F"
+//	    Mask uint8  `csv:"mask,base=0"` // "0b1010", "0o17", "0xff" or "12"
+//	}
+//
+// # Boolean Values
+//
+// Files that spell booleans in other words can list them per field or for the
+// whole iterator. CSVWriter writes the first word of each list:
+//
+//	type Member struct {
+//	    Partner bool `csv:"partner,true=ja|j,false=nein|n"`
+//	}
+//
+//	iterator, err := supercsv.New[Member](src, supercsv.WithBoolValues([]string{"Y"}, []string{"N"}))
+//
+// # Validation
+//
+// Fields can declare rules that every value must satisfy. They are compiled
+// when the iterator is created, so a bad rule is reported up front:
+//
+//	type Member struct {
+//	    Age     int      `csv:"age,min=0,max=150"`
+//	    Country string   `csv:"country,len=2"`
+//	    Name    string   `csv:"name,maxlen=40"`
+//	    Status  string   `csv:"status,oneof=active|inactive"`
+//	    Code    string   `csv:"code,pattern=^[A-Z]{2}-[0-9]+$"`
+//	    Tags    []string `csv:"tag_*,max=3"`
+//	}
+//
+// min and max compare numbers and durations by value, and strings, slices
+// and arrays by length. pattern is unanchored and takes the rest of the tag,
+// so it must come last; an option written after it is an error. Empty cells
+// are not checked; use required for that. A failing value is reported as a
+// *ParseError wrapping a *ValidationError that names the rule, and is handled
+// by the error policy like any other bad row.
+//
+// Rules spanning several fields go in a Validate method on the row type,
+// which Next calls after decoding, or in a function passed to WithValidator:
+//
+//	func (b *Booking) Validate() error {
+//	    if !b.End.After(b.Start) {
+//	        return errors.New("end must be after start")
+//	    }
+//	    return nil
+//	}
+//
+//	iterator, err := supercsv.New[OrderLine](src, supercsv.WithValidator(func(l *OrderLine) error {
+//	    if float64(l.Qty)*l.Price != l.Total {
+//	        return errors.New("total is not qty × price")
+//	    }
+//	    return nil
+//	}))
+//
+// Their errors are reported as a *ParseError wrapping a *RowValidationError,
+// and are handled by the error policy.
+//
+// # Strict Columns
+//
+// Columns in the file that no field reads are ignored by default;
+// UnmappedColumns lists them. WithStrictColumns turns them into a construction
+// error, so upstream schema changes are noticed, with an allowlist for columns
+// that may safely be ignored:
+//
+//	iterator, err := supercsv.New[Person](src, supercsv.WithStrictColumns("notes", "internal_id"))
+//
+// # Catch-All Columns
+//
+// A map[string]string field tagged with the rest option receives every column
+// not claimed by another field, keyed by header name:
+//
+//	type CatalogItem struct {
+//	    SKU        string            `csv:"sku,required"`
+//	    Attributes map[string]string `csv:",rest"`
+//	}
+//
+// Unclaimed columns sharing a name follow the duplicate policy, so one value
+// never silently overwrites another in the map.
+//
+// CSVWriter writes the keys of the first item's rest map as extra columns, in
+// sorted order, after the regular fields.
+//
+// # Nested Structs
+//
+// Embedded structs are flattened as if their fields were declared inline. A
+// named struct field tagged prefix reads its fields from columns carrying the
+// prefix, so one type can be reused for several column groups:
+//
+//	type Address struct {
+//	    Street string `csv:"street"`
+//	    City   string `csv:"city"`
+//	}
+//
+//	type Order struct {
+//	    Audit                      // Embedded, columns of Audit are read as-is
+//	    Billing  Address  `csv:"billing_,prefix"`  // billing_street, billing_city
+//	    Shipping *Address `csv:"shipping_,prefix"` // Left nil if all columns are empty
+//	}
+//
+// Parse errors name the field by its path, such as "Billing.City".
+//
+// # Headerless Files
+//
+// WithNoHeader treats the first record as data. Fields are then bound by
+// zero-based position with index tags:
+//
+//	type Transaction struct {
+//	    Date   time.Time `csv:"#0,required"`
+//	    Amount float64   `csv:"#2"`
+//	}
+//
+//	iterator, err := supercsv.New[Transaction](src, supercsv.WithNoHeader())
+//
+// Index and name tags can be mixed when the file has a header. When writing,
+// positional fields are placed at their index and WithNoHeader omits the header row.
+//
 // # Supported Types
 //
 //   - string: Direct string values, no conversion needed
-//   - Integers: int, int8, int16, int32, int64 (decimal format)
-//   - Unsigned integers: uint, uint8, uint16, uint32, uint64 (decimal format)
+//   - Integers: int, int8, int16, int32, int64 (decimal format, or base=16 / base=0 tag option)
+//   - Unsigned integers: uint, uint8, uint16, uint32, uint64 (same as integers)
 //   - Floating point: float32, float64 (decimal format, scientific notation supported)
-//   - Boolean: bool (accepts: true/false, 1/0, yes/no, on/off, case insensitive)
+//   - Boolean: bool (accepts: true/false, t/f, 1/0, yes/no, y/n, on/off, case
+//     insensitive; configurable with WithBoolValues or true=/false= tag options)
 //   - Time: time.Time (multiple format auto-detection, see Time Parsing section)
+//   - Durations: time.Duration ("1h30m"; bare numbers with a unit=ms tag option, where
+//     the unit is one of ns, us, ms, s, m or h)
+//   - Arbitrary precision: big.Int, big.Float (keeping every digit), big.Rat
 //   - Pointers: *T where T is any supported type above (for optional/nullable fields)
+//   - Custom types: anything with a registered converter, or implementing
+//     CSVUnmarshaler or encoding.TextUnmarshaler (see Custom Types section)
 //
 // Empty CSV values are handled as follows:
 //   - Regular fields: Set to their zero value (0, "", false, time.Time{})
 //   - Pointer fields: Set to nil
 //   - Required fields: Generate an error if empty
 //
+// # Custom Types
+//
+// Before the built-in conversions are tried, each cell is offered to:
+//
+//  1. A converter registered for the exact field type with RegisterConverter
+//  2. A CSVUnmarshaler implementation, which also receives the column name
+//  3. An encoding.TextUnmarshaler implementation (uuid.UUID, netip.Addr, ...)
+//
+// For example:
+//
+//	supercsv.RegisterConverter(func(s string) (decimal.Decimal, error) {
+//	    return decimal.NewFromString(s)
+//	})
+//
+//	func (s *Status) UnmarshalCSV(value, column string) error {
+//	    ...
+//	}
+//
+// time.Time keeps its multi-format parsing even though it implements
+// encoding.TextUnmarshaler. CSVWriter formats types implementing
+// encoding.TextMarshaler with MarshalText.
+//
 // # Time Parsing
 //
-// The time.Time type supports automatic format detection for common date/time formats:
+// The time.Time type supports automatic format detection for unambiguous date/time formats:
 //
 //   - RFC3339: "2006-01-02T15:04:05Z07:00"
-//   - RFC3339Nano: "2006-01-02T15:04:05.999999999Z07:00" 
+//   - RFC3339Nano: "2006-01-02T15:04:05.999999999Z07:00"
 //   - SQL DateTime: "2006-01-02 15:04:05"
 //   - Date only: "2006-01-02"
 //   - Time only: "15:04:05"
-//   - US date: "01/02/2006"
-//   - US datetime: "01/02/2006 15:04:05"
-//   - European date: "02/01/2006"
-//   - European datetime: "02/01/2006 15:04:05"
 //
-// For cross-platform consistency, dates without explicit timezone information
-// are parsed as UTC. Only RFC3339 formats with explicit timezone are preserved.
+// Numeric dates like "03/04/2024" are ambiguous (March 4 or April 3), so they
+// are only accepted after opting in to a date order:
+//
+//	// US: "01/02/2006" and "01/02/2006 15:04:05"
+//	iterator, err := supercsv.New[Event](src, supercsv.WithDateOrder(supercsv.MonthFirst))
+//
+//	// European: "02/01/2006" and "02/01/2006 15:04:05"
+//	iterator, err := supercsv.New[Event](src, supercsv.WithDateOrder(supercsv.DayFirst))
+//
+// WithTimeFormats replaces the auto-detection list entirely, and
+// WithTimeLocation changes the location used for times without explicit
+// timezone. By default they are parsed as UTC for cross-platform consistency.
+//
+// Individual fields can override this with tag options:
+//
+//	type Shipment struct {
+//	    Created   time.Time  `csv:"created,layout=02.01.2006"`
+//	    Delivered *time.Time `csv:"delivered,layout=2006-01-02 15:04,tz=Europe/Berlin"`
+//	    Scanned   time.Time  `csv:"scanned,unix"`   // Seconds since epoch
+//	    Logged    time.Time  `csv:"logged,unixms"`  // Also: unixnano
+//	}
+//
+// A layout= option makes that layout the only accepted format for the field.
+// Since tag options are comma separated, layouts cannot contain commas.
+// CSVWriter uses the same layout, tz, and unix options when formatting.
 //
 // Example with time fields:
 //
@@ -100,7 +369,7 @@
 //	    EndTime   *time.Time `csv:"end_time"` // Optional
 //	}
 //
-//	// CSV data with various time formats:
+//	// CSV data with various time formats (using WithDateOrder(supercsv.MonthFirst)):
 //	// event_name,start_date,end_time
 //	// "Conference",2024-03-15,2024-03-15T18:00:00Z
 //	// "Workshop","03/20/2024 09:00:00",
@@ -109,7 +378,7 @@
 //
 // Time Parsing Notes:
 //   - Formats are tried in order until one succeeds
-//   - All formats without explicit timezone are parsed as UTC for cross-platform consistency
+//   - All formats without explicit timezone are parsed as UTC unless configured otherwise
 //   - RFC3339 formats with timezone information preserve the original timezone
 //   - Empty time fields result in time.Time{} (zero value) or nil for pointer fields
 //   - Invalid time formats return descriptive error messages with supported formats
@@ -119,17 +388,17 @@
 // If you encounter time parsing errors, check the following:
 //
 //   - Ensure your date format matches one of the supported patterns
-//   - For custom formats, consider preprocessing your CSV data
+//   - For numeric dates with slashes, choose a date order with WithDateOrder
+//   - For custom formats, use a layout= tag option or WithTimeFormats
 //   - Use RFC3339 format ("2006-01-02T15:04:05Z07:00") for maximum compatibility
 //   - Check for extra whitespace around date values in your CSV
-//   - Verify that date separators match expected patterns (/ vs - vs space)
 //
 // Common time format examples:
 //   - "2024-03-15" → March 15, 2024 (date only)
 //   - "2024-03-15 14:30:00" → March 15, 2024 at 2:30 PM
 //   - "2024-03-15T14:30:00Z" → March 15, 2024 at 2:30 PM UTC
-//   - "03/15/2024" → March 15, 2024 (US format)
-//   - "15/03/2024" → March 15, 2024 (European format)
+//   - "03/15/2024" → March 15, 2024 (with MonthFirst)
+//   - "15/03/2024" → March 15, 2024 (with DayFirst)
 //
 // # Error Handling
 //
@@ -142,6 +411,32 @@
 //   - Network errors (for URL sources)
 //   - Time format mismatches with helpful format suggestions
 //
+// Errors returned by Next, ToSlice, and ForEach for a row are *ParseError
+// values carrying the row index, input line and column, header name, struct
+// field, raw cell value, and the underlying error (for example a
+// *strconv.NumError or *csv.ParseError):
+//
+//	var perr *supercsv.ParseError
+//	if errors.As(err, &perr) {
+//	    log.Printf("line %d, column %s, value %q: %v", perr.Line, perr.Header, perr.Value, perr.Err)
+//	}
+//
+// By default the first bad row stops iteration. WithErrorPolicy changes this
+// so that bad rows are skipped and collected instead:
+//
+//	var rejects bytes.Buffer
+//	iterator, err := supercsv.New[Person](src,
+//	    supercsv.WithErrorPolicy(supercsv.SkipAndCollect), // or MaxErrors(100)
+//	    supercsv.WithRejectWriter(&rejects),                // raw bad records, with header
+//	)
+//	people, err := iterator.ToSlice() // good rows only
+//	for _, perr := range iterator.Errors() {
+//	    log.Printf("skipped: %v", perr)
+//	}
+//
+// With MaxErrors(n), the (n+1)th bad row makes Next return an error wrapping
+// ErrTooManyErrors; MaxErrors(0) does so on the first bad row. Errors in the underlying stream always stop iteration.
+//
 // # Data Sources
 //
 // SuperCSV supports multiple data sources:
@@ -155,6 +450,84 @@
 //	// From any io.Reader
 //	iterator, err := supercsv.NewFromReader[Person](reader)
 //
+//	// From URL with a custom client, bound to a context
+//	iterator, err := supercsv.NewFromURLWithClient[Person](ctx, client, "https://example.com/data.csv")
+//
+// # Compression
+//
+// All sources detect gzip, bzip2, zstd, and xz data by its magic bytes (and
+// URLs also by Content-Encoding) and decompress it transparently. zstd and xz
+// use github.com/klauspost/compress and github.com/ulikunitz/xz; another
+// implementation can be swapped in with RegisterDecompressor.
+//
+// WithCompression overrides detection, and Close closes both the decompressor
+// and the underlying source.
+//
+// # Text Encoding
+//
+// A UTF-8 byte order mark, as written by Excel, is stripped automatically,
+// and a UTF-16 BOM switches to UTF-16 decoding. Other encodings can be
+// selected explicitly:
+//
+//	iterator, err := supercsv.New[Person](src, supercsv.WithEncoding(supercsv.Windows1252))
+//
+// Latin1, Windows1252, UTF16LE, and UTF16BE are built in. Decoders from
+// golang.org/x/text can be adapted with DecoderFunc:
+//
+//	supercsv.WithEncoding(supercsv.DecoderFunc(charmap.ISO8859_15.NewDecoder().Reader))
+//
+// # Cancellation
+//
+// NextContext returns ctx.Err() once ctx is done. If a read is blocked on a
+// closable source such as an HTTP body, the source is closed to interrupt it,
+// after which the iterator cannot be used further. WithContext binds a context
+// to the whole iterator so that URL requests use it and Next behaves like
+// NextContext:
+//
+//	iterator, err := supercsv.New[Person](supercsv.FromURL(url), supercsv.WithContext(ctx))
+//
+// # Options
+//
+// New is the general-purpose constructor. It takes a Source and any number of
+// functional options that configure the underlying csv.Reader:
+//
+//	iterator, err := supercsv.New[Person](supercsv.FromFile("data.csv"),
+//	    supercsv.WithDelimiter(';'),
+//	    supercsv.WithComment('#'),
+//	    supercsv.WithLazyQuotes(),
+//	    supercsv.WithTrimLeadingSpace(),
+//	    supercsv.WithFieldsPerRecord(0),
+//	    supercsv.WithHeaderRow(2), // Header is the third record
+//	    supercsv.WithSkipRows(1),  // Skip the first data row
+//	)
+//
+// The NewFromX and NewFromXWithDelimiter constructors are thin wrappers around New.
+//
+// # Range Iteration
+//
+// All and Rows return Go 1.23 range-over-func iterators:
+//
+//	for person, err := range iterator.All() {
+//	    if err != nil {
+//	        log.Printf("Error: %v", err)
+//	        continue
+//	    }
+//	    processPerson(person)
+//	}
+//
+//	for row, person := range iterator.Rows() {
+//	    fmt.Printf("%d: %s\n", row, person.Name)
+//	}
+//	if err := iterator.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Breaking out of the loop stops reading. With WithAutoClose the source is also
+// closed when the loop ends. Collect, Filter, and Map compose iter.Seq2 values:
+//
+//	adults := supercsv.Filter(iterator.All(), func(p *Person) bool { return p.Age >= 18 })
+//	people, err := supercsv.Collect(adults)
+//
 // # Batch Operations
 //
 // For convenience, SuperCSV provides batch processing methods:
@@ -172,6 +545,23 @@
 //	    return true // Continue
 //	})
 //
+// # Writing CSV
+//
+// CSVWriter uses the same csv tags to write structs back out, so data can be
+// cleaned or enriched and re-exported without duplicating column mappings:
+//
+//	writer, err := supercsv.NewWriterToFile[Person]("out.csv")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer writer.Close()
+//
+//	// Header row is written from the csv tags, then one row per struct
+//	err = writer.WriteAll(people)
+//
+// Columns are emitted in struct field order. Nil pointers and zero time.Time
+// values are written as empty cells, and times are formatted as RFC3339.
+//
 // # Advanced Example with Time Fields
 //
 // Here's a comprehensive example showing time.Time usage with different formats:
@@ -190,7 +580,8 @@
 //	// 2,"User login","2024-03-15 10:31:25",,DEBUG
 //	// 3,"Error occurred","03/15/2024 10:32:00","12/31/2024","ERROR"
 //
-//	iterator, err := supercsv.NewFromFile[LogEntry]("logs.csv")
+//	iterator, err := supercsv.New[LogEntry](supercsv.FromFile("logs.csv"),
+//	    supercsv.WithDateOrder(supercsv.MonthFirst))
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -0,0 +1,90 @@
+package supercsv
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DuplicatePolicy controls how a field bound to a header name that appears
+// more than once chooses its column
+type DuplicatePolicy int
+
+const (
+	// DuplicateError fails construction when a field is bound to a duplicated header (the default)
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateFirst reads the first column with the name
+	DuplicateFirst
+	// DuplicateLast reads the last column with the name
+	DuplicateLast
+)
+
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicateError:
+		return "error"
+	case DuplicateFirst:
+		return "first"
+	case DuplicateLast:
+		return "last"
+	}
+	return fmt.Sprintf("DuplicatePolicy(%d)", int(p))
+}
+
+// DuplicateColumn describes a header name found more than once
+type DuplicateColumn struct {
+	Header  string          // Header name as it first appears
+	Columns []int           // Zero-based positions of every column with the name
+	Field   string          // Struct field bound to the name, empty if none
+	Used    []int           // Positions actually read by Field
+	Policy  DuplicatePolicy // Policy in effect when the iterator was created
+}
+
+func (d DuplicateColumn) String() string {
+	if d.Field == "" {
+		return fmt.Sprintf("column '%s' at positions %s is unused", d.Header, formatPositions(d.Columns))
+	}
+	return fmt.Sprintf("column '%s' at positions %s: field %s reads %s", d.Header, formatPositions(d.Columns), d.Field, formatPositions(d.Used))
+}
+
+// findDuplicates builds the duplicate header diagnostics in header order
+func findDuplicates(headers []string, fieldMap map[string][]int, fields []fieldInfo, cfg *config) []DuplicateColumn {
+	var duplicates []DuplicateColumn
+
+	for i, header := range headers {
+		columns := fieldMap[cfg.normalizeHeader(strings.TrimSpace(header))]
+		if len(columns) < 2 || columns[0] != i {
+			continue
+		}
+
+		duplicate := DuplicateColumn{
+			Header:  strings.TrimSpace(header),
+			Columns: columns,
+			Policy:  cfg.duplicatePolicy,
+		}
+		for _, field := range fields {
+			switch {
+			case field.columnIndexes != nil && slices.Equal(field.columnIndexes, columns):
+				duplicate.Used = field.columnIndexes
+			case field.columnIndexes == nil && slices.Contains(columns, field.columnIndex):
+				duplicate.Used = []int{field.columnIndex}
+			default:
+				continue
+			}
+			duplicate.Field = field.name
+			break
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	return duplicates
+}
+
+func formatPositions(positions []int) string {
+	parts := make([]string, len(positions))
+	for i, p := range positions {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ", ")
+}
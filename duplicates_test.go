@@ -0,0 +1,107 @@
+package supercsv
+
+import (
+	"strings"
+	"testing"
+)
+
+const duplicateCSV = `id,name,id,note
+1,Widget,A-1,first
+2,Gadget,,second`
+
+type DuplicateRecord struct {
+	ID   string `csv:"id"`
+	Name string `csv:"name"`
+}
+
+func TestDuplicatePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   DuplicatePolicy
+		expected []string
+		wantErr  string
+	}{
+		{name: "error by default", policy: DuplicateError, wantErr: "duplicate CSV column 'id' at positions 0, 2 for field ID"},
+		{name: "first", policy: DuplicateFirst, expected: []string{"1", "2"}},
+		{name: "last", policy: DuplicateLast, expected: []string{"A-1", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator, err := New[DuplicateRecord](FromReader(strings.NewReader(duplicateCSV)), WithDuplicatePolicy(tt.policy))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to create iterator: %v", err)
+			}
+			defer iterator.Close()
+
+			records, err := iterator.ToSlice()
+			if err != nil {
+				t.Fatalf("Failed to read records: %v", err)
+			}
+			for i, record := range records {
+				if record.ID != tt.expected[i] {
+					t.Errorf("Row %d: expected ID %q, got %q", i+1, tt.expected[i], record.ID)
+				}
+			}
+
+			duplicates := iterator.Duplicates()
+			if len(duplicates) != 1 || duplicates[0].Field != "ID" || duplicates[0].Policy != tt.policy {
+				t.Errorf("Unexpected duplicates: %v", duplicates)
+			}
+		})
+	}
+}
+
+func TestDuplicateColumns_Slice(t *testing.T) {
+	type Record struct {
+		IDs  []string `csv:"id"`
+		Name string   `csv:"name"`
+	}
+
+	iterator, err := NewFromReader[Record](strings.NewReader(duplicateCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	records, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+
+	if strings.Join(records[0].IDs, "|") != "1|A-1" {
+		t.Errorf("Expected IDs [1 A-1], got %v", records[0].IDs)
+	}
+	if strings.Join(records[1].IDs, "|") != "2" {
+		t.Errorf("Expected IDs [2], got %v", records[1].IDs)
+	}
+
+	duplicates := iterator.Duplicates()
+	expected := "column 'id' at positions 0, 2: field IDs reads 0, 2"
+	if len(duplicates) != 1 || duplicates[0].String() != expected {
+		t.Errorf("Expected diagnostic %q, got %v", expected, duplicates)
+	}
+}
+
+func TestDuplicateColumns_Unused(t *testing.T) {
+	type Record struct {
+		Name string `csv:"name"`
+	}
+
+	iterator, err := NewFromReader[Record](strings.NewReader(duplicateCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	duplicates := iterator.Duplicates()
+	if len(duplicates) != 1 || duplicates[0].String() != "column 'id' at positions 0, 2 is unused" {
+		t.Errorf("Unexpected duplicates: %v", duplicates)
+	}
+}
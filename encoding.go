@@ -0,0 +1,171 @@
+package supercsv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding converts a source in some character encoding to UTF-8
+type Encoding interface {
+	NewReader(r io.Reader) io.Reader
+}
+
+// DecoderFunc adapts a function to the Encoding interface. It makes decoders
+// from golang.org/x/text usable directly:
+//
+//	supercsv.WithEncoding(supercsv.DecoderFunc(charmap.ISO8859_15.NewDecoder().Reader))
+type DecoderFunc func(r io.Reader) io.Reader
+
+// NewReader calls f(r)
+func (f DecoderFunc) NewReader(r io.Reader) io.Reader {
+	return f(r)
+}
+
+var (
+	// Latin1 decodes ISO-8859-1
+	Latin1 Encoding = singleByteEncoding{table: latin1Table()}
+	// Windows1252 decodes Windows code page 1252, common in legacy Excel and ERP exports
+	Windows1252 Encoding = singleByteEncoding{table: windows1252Table()}
+	// UTF16LE decodes little-endian UTF-16
+	UTF16LE Encoding = utf16Encoding{order: binary.LittleEndian}
+	// UTF16BE decodes big-endian UTF-16
+	UTF16BE Encoding = utf16Encoding{order: binary.BigEndian}
+)
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// decodeText strips a byte order mark and converts the stream to UTF-8.
+// A UTF-16 BOM selects UTF-16 decoding; otherwise the configured encoding is used.
+func decodeText(reader io.Reader, enc Encoding) io.Reader {
+	buffered := bufio.NewReader(reader)
+	header, _ := buffered.Peek(3)
+
+	switch {
+	case bytes.HasPrefix(header, bomUTF8):
+		buffered.Discard(len(bomUTF8))
+		return buffered
+	case bytes.HasPrefix(header, bomUTF16LE):
+		buffered.Discard(len(bomUTF16LE))
+		return UTF16LE.NewReader(buffered)
+	case bytes.HasPrefix(header, bomUTF16BE):
+		buffered.Discard(len(bomUTF16BE))
+		return UTF16BE.NewReader(buffered)
+	}
+
+	if enc != nil {
+		return enc.NewReader(buffered)
+	}
+	return buffered
+}
+
+type singleByteEncoding struct {
+	table *[256]rune
+}
+
+func (e singleByteEncoding) NewReader(r io.Reader) io.Reader {
+	return &decodingReader{r: r, decode: func(dst, src []byte, atEOF bool) ([]byte, int) {
+		for _, b := range src {
+			dst = utf8.AppendRune(dst, e.table[b])
+		}
+		return dst, len(src)
+	}}
+}
+
+func latin1Table() *[256]rune {
+	var table [256]rune
+	for i := range table {
+		table[i] = rune(i)
+	}
+	return &table
+}
+
+func windows1252Table() *[256]rune {
+	table := latin1Table()
+	for b, r := range map[byte]rune{
+		0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+		0x88: 'ˆ', 0x89: '‰', 0x8a: 'Š', 0x8b: '‹', 0x8c: 'Œ', 0x8e: 'Ž',
+		0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+		0x98: '˜', 0x99: '™', 0x9a: 'š', 0x9b: '›', 0x9c: 'œ', 0x9e: 'ž', 0x9f: 'Ÿ',
+	} {
+		table[b] = r
+	}
+	return table
+}
+
+type utf16Encoding struct {
+	order binary.ByteOrder
+}
+
+func (e utf16Encoding) NewReader(r io.Reader) io.Reader {
+	return &decodingReader{r: r, decode: func(dst, src []byte, atEOF bool) ([]byte, int) {
+		i := 0
+		for i+1 < len(src) {
+			r1 := rune(e.order.Uint16(src[i:]))
+			if !utf16.IsSurrogate(r1) {
+				dst = utf8.AppendRune(dst, r1)
+				i += 2
+				continue
+			}
+			if i+3 >= len(src) && !atEOF {
+				break // Wait for the second half of the pair
+			}
+			if i+3 < len(src) {
+				r2 := rune(e.order.Uint16(src[i+2:]))
+				if decoded := utf16.DecodeRune(r1, r2); decoded != utf8.RuneError {
+					dst = utf8.AppendRune(dst, decoded)
+					i += 4
+					continue
+				}
+			}
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+			i += 2
+		}
+		if atEOF && i < len(src) {
+			dst = utf8.AppendRune(dst, utf8.RuneError) // Odd trailing byte
+			i = len(src)
+		}
+		return dst, i
+	}}
+}
+
+// decodingReader converts chunks of its source with decode, which appends the
+// UTF-8 form of src to dst and reports how many bytes of src it consumed
+type decodingReader struct {
+	r      io.Reader
+	decode func(dst, src []byte, atEOF bool) ([]byte, int)
+	chunk  []byte
+	src    []byte
+	out    []byte
+	err    error
+}
+
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.chunk == nil {
+			d.chunk = make([]byte, 4096)
+		}
+
+		n, err := d.r.Read(d.chunk)
+		d.src = append(d.src, d.chunk[:n]...)
+		d.err = err
+
+		var consumed int
+		d.out, consumed = d.decode(d.out[:0], d.src, err != nil)
+		d.src = append(d.src[:0], d.src[consumed:]...)
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
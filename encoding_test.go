@@ -0,0 +1,120 @@
+package supercsv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func encodeUTF16(s string, order binary.AppendByteOrder, bom []byte) []byte {
+	buf := append([]byte(nil), bom...)
+	for _, u := range utf16.Encode([]rune(s)) {
+		buf = order.AppendUint16(buf, u)
+	}
+	return buf
+}
+
+func TestEncoding_UTF8BOM(t *testing.T) {
+	data := append([]byte{0xef, 0xbb, 0xbf}, "name,age,email\nJohn Doe,30,john@example.com\n"...)
+
+	iterator, err := NewFromReader[Person](bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	if iterator.Headers()[0] != "name" {
+		t.Errorf("Expected BOM to be stripped from first header, got %q", iterator.Headers()[0])
+	}
+}
+
+func TestEncoding_UTF16(t *testing.T) {
+	csvData := "name,age,email\nJosé 😀,30,jose@example.com\n"
+
+	tests := []struct {
+		name string
+		data []byte
+		opts []Option
+	}{
+		{name: "little endian BOM", data: encodeUTF16(csvData, binary.LittleEndian, []byte{0xff, 0xfe})},
+		{name: "big endian BOM", data: encodeUTF16(csvData, binary.BigEndian, []byte{0xfe, 0xff})},
+		{name: "explicit big endian", data: encodeUTF16(csvData, binary.BigEndian, nil), opts: []Option{WithEncoding(UTF16BE)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Splitting the input exercises code units split across reads
+			reader := io.MultiReader(bytes.NewReader(tt.data[:1]), bytes.NewReader(tt.data[1:]))
+			iterator, err := New[Person](FromReader(reader), tt.opts...)
+			if err != nil {
+				t.Fatalf("Failed to create iterator: %v", err)
+			}
+			defer iterator.Close()
+
+			person, err := iterator.Next()
+			if err != nil {
+				t.Fatalf("Failed to read person: %v", err)
+			}
+			if person.Name != "José 😀" {
+				t.Errorf("Expected name 'José 😀', got %q", person.Name)
+			}
+		})
+	}
+}
+
+func TestEncoding_SingleByte(t *testing.T) {
+	// "Café" and a euro sign in Windows-1252
+	data := []byte("name,age,email\nCaf\xe9 \x80,30,cafe@example.com\n")
+
+	iterator, err := New[Person](FromReader(bytes.NewReader(data)), WithEncoding(Windows1252))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	person, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read person: %v", err)
+	}
+	if person.Name != "Café €" {
+		t.Errorf("Expected name 'Café €', got %q", person.Name)
+	}
+
+	iterator, err = New[Person](FromReader(bytes.NewReader(data)), WithEncoding(Latin1))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	person, err = iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read person: %v", err)
+	}
+	if person.Name != "Café \u0080" {
+		t.Errorf("Expected name 'Café \\u0080', got %q", person.Name)
+	}
+}
+
+func TestEncoding_DecoderFunc(t *testing.T) {
+	lower := DecoderFunc(func(r io.Reader) io.Reader {
+		data, _ := io.ReadAll(r)
+		return strings.NewReader(strings.ToLower(string(data)))
+	})
+
+	iterator, err := New[Person](FromReader(strings.NewReader("NAME,AGE,EMAIL\nJOHN,30,JOHN@EXAMPLE.COM\n")), WithEncoding(lower))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	person, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read person: %v", err)
+	}
+	if person.Name != "john" {
+		t.Errorf("Expected name 'john', got %q", person.Name)
+	}
+}
@@ -0,0 +1,122 @@
+package supercsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTooManyErrors is returned by Next when the number of bad rows exceeds
+// the limit set with MaxErrors
+var ErrTooManyErrors = errors.New("too many errors")
+
+// ErrorPolicy controls how an iterator handles rows that fail to parse.
+// Errors in the underlying stream (such as network failures) always stop iteration.
+type ErrorPolicy struct {
+	skip  bool
+	limit int // Bad rows tolerated when skipping, or -1 for no limit
+}
+
+var (
+	// FailFast returns the first row error from Next (the default)
+	FailFast = ErrorPolicy{}
+	// SkipAndCollect skips every bad row; inspect them with CSVIterator.Errors
+	SkipAndCollect = ErrorPolicy{skip: true, limit: -1}
+)
+
+// MaxErrors skips up to n bad rows, then returns an error wrapping
+// ErrTooManyErrors. A negative n is treated as 0, so the first bad row
+// returns that error.
+func MaxErrors(n int) ErrorPolicy {
+	return ErrorPolicy{skip: true, limit: max(n, 0)}
+}
+
+func (p ErrorPolicy) tolerates(errorCount int) bool {
+	return p.skip && (p.limit < 0 || errorCount <= p.limit)
+}
+
+// isRecoverable reports whether iteration can continue past the error:
+// decoding errors and malformed records can be skipped, stream errors cannot
+func isRecoverable(perr *ParseError) bool {
+	var csvErr *csv.ParseError
+	var rowErr *RowValidationError
+	return perr.Field != "" || errors.As(perr.Err, &csvErr) || errors.As(perr.Err, &rowErr)
+}
+
+// ParseError describes a failure to read or decode a single CSV row.
+// Use errors.As to inspect it:
+//
+//	var perr *supercsv.ParseError
+//	if errors.As(err, &perr) {
+//	    fmt.Printf("line %d, column %s, value %q\n", perr.Line, perr.Header, perr.Value)
+//	}
+type ParseError struct {
+	Row    int    // 1-based index of the data row (the header is not counted)
+	Line   int    // 1-based line in the input where the error occurred
+	Column int    // 1-based byte column in the line, as reported by encoding/csv
+	Header string // CSV column name, empty for record-level errors
+	Field  string // Struct field name, empty for record-level errors
+	Value  string // Raw cell value
+	Err    error  // Underlying error, such as *strconv.NumError, *OverflowError, *ValidationError or *csv.ParseError
+}
+
+func (e *ParseError) Error() string {
+	location := fmt.Sprintf("row %d", e.Row)
+	if e.Line > 0 {
+		location = fmt.Sprintf("row %d (line %d)", e.Row, e.Line)
+	}
+
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %v", location, e.Err)
+	}
+	return fmt.Sprintf("%s: failed to parse field %s (column %s): %v", location, e.Field, e.Header, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// OverflowError reports a number that does not fit in its field's type,
+// such as 300 for an int8. It unwraps to the *strconv.NumError, so
+// errors.Is(err, strconv.ErrRange) also matches.
+type OverflowError struct {
+	Value string       // Cell value as read
+	Type  reflect.Type // Field type the value was parsed into
+	Err   error
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %s overflows %s", e.Value, e.Type)
+}
+
+func (e *OverflowError) Unwrap() error {
+	return e.Err
+}
+
+// RowValidationError wraps an error returned by a row's Validate method or
+// the WithValidator function. The row itself is discarded.
+type RowValidationError struct {
+	Err error
+}
+
+func (e *RowValidationError) Error() string {
+	return fmt.Sprintf("row validation failed: %v", e.Err)
+}
+
+func (e *RowValidationError) Unwrap() error {
+	return e.Err
+}
+
+// newRecordError wraps an error returned by csv.Reader for the given row
+func newRecordError(row int, err error) *ParseError {
+	perr := &ParseError{Row: row, Err: err}
+
+	var csvErr *csv.ParseError
+	if errors.As(err, &csvErr) {
+		perr.Line = csvErr.Line
+		perr.Column = csvErr.Column
+	}
+
+	return perr
+}
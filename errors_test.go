@@ -0,0 +1,171 @@
+package supercsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseError_FieldError(t *testing.T) {
+	csvData := `name,age,email,salary,active
+John Doe,30,john@example.com,50000,true
+Jane Smith,25,jane@example.com,"12,5",false`
+
+	iterator, err := NewFromReader[Person](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.ToSlice()
+	if err == nil {
+		t.Fatal("Expected error for invalid salary")
+	}
+
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Expected *ParseError, got %T: %v", err, err)
+	}
+	if perr.Row != 2 || perr.Line != 3 || perr.Column != 32 {
+		t.Errorf("Expected row 2, line 3, column 32, got row %d, line %d, column %d", perr.Row, perr.Line, perr.Column)
+	}
+	if perr.Header != "salary" || perr.Field != "Salary" || perr.Value != "12,5" {
+		t.Errorf("Unexpected header/field/value: %q/%q/%q", perr.Header, perr.Field, perr.Value)
+	}
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected underlying strconv syntax error, got %v", perr.Err)
+	}
+
+	expected := `row 2 (line 3): failed to parse field Salary (column salary): invalid float: strconv.ParseFloat: parsing "12,5": invalid syntax`
+	if err.Error() != expected {
+		t.Errorf("Expected message %q, got %q", expected, err.Error())
+	}
+}
+
+func TestParseError_RecordError(t *testing.T) {
+	csvData := `name,age,email
+John Doe,30,john@example.com
+Jane "Smith,25,jane@example.com`
+
+	iterator, err := NewFromReader[Person](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	var errs []error
+	iterator.ForEach(func(person *Person, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return true
+	})
+
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(errs), errs)
+	}
+
+	var perr *ParseError
+	if !errors.As(errs[0], &perr) {
+		t.Fatalf("Expected *ParseError, got %T: %v", errs[0], errs[0])
+	}
+	if perr.Row != 2 || perr.Line != 3 || perr.Field != "" {
+		t.Errorf("Expected record error on row 2, line 3, got %+v", perr)
+	}
+
+	var csvErr *csv.ParseError
+	if !errors.As(errs[0], &csvErr) {
+		t.Errorf("Expected underlying *csv.ParseError, got %v", perr.Err)
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	csvData := `name,age,email
+John Doe,30,john@example.com
+Bad Age,abc,bad@example.com
+Jane "Smith,25,jane@example.com
+Bob Johnson,35,bob@example.com
+Another Bad,x,x@example.com`
+
+	tests := []struct {
+		name       string
+		policy     ErrorPolicy
+		wantPeople int
+		wantErrors int
+		wantErr    error
+	}{
+		{name: "skip and collect", policy: SkipAndCollect, wantPeople: 2, wantErrors: 3},
+		{name: "max errors not exceeded", policy: MaxErrors(3), wantPeople: 2, wantErrors: 3},
+		{name: "max errors exceeded", policy: MaxErrors(2), wantErrors: 3, wantErr: ErrTooManyErrors},
+		{name: "fail fast", policy: FailFast, wantErrors: 1, wantErr: strconv.ErrSyntax},
+		{name: "max errors zero", policy: MaxErrors(0), wantErrors: 1, wantErr: ErrTooManyErrors},
+		{name: "max errors negative", policy: MaxErrors(-1), wantErrors: 1, wantErr: ErrTooManyErrors},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator, err := New[Person](FromReader(strings.NewReader(csvData)), WithErrorPolicy(tt.policy))
+			if err != nil {
+				t.Fatalf("Failed to create iterator: %v", err)
+			}
+			defer iterator.Close()
+
+			people, err := iterator.ToSlice()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error wrapping %v, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(people) != tt.wantPeople {
+				t.Errorf("Expected %d people, got %d", tt.wantPeople, len(people))
+			}
+			if len(iterator.Errors()) != tt.wantErrors {
+				t.Errorf("Expected %d collected errors, got %d: %v", tt.wantErrors, len(iterator.Errors()), iterator.Errors())
+			}
+		})
+	}
+}
+
+func TestErrorPolicy_RejectWriter(t *testing.T) {
+	csvData := `name;age;email
+John Doe;30;john@example.com
+Bad Age;abc;bad@example.com
+Short;1
+Bob Johnson;35;bob@example.com`
+
+	var rejects strings.Builder
+	iterator, err := New[Person](FromReader(strings.NewReader(csvData)),
+		WithDelimiter(';'),
+		WithFieldsPerRecord(0),
+		WithErrorPolicy(SkipAndCollect),
+		WithRejectWriter(&rejects),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	people, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(people) != 2 {
+		t.Errorf("Expected 2 people, got %d", len(people))
+	}
+
+	expected := "name;age;email\nBad Age;abc;bad@example.com\nShort;1\n"
+	if rejects.String() != expected {
+		t.Errorf("Expected rejects %q, got %q", expected, rejects.String())
+	}
+
+	if rows := []int{iterator.Errors()[0].Row, iterator.Errors()[1].Row}; rows[0] != 2 || rows[1] != 3 {
+		t.Errorf("Expected errors on rows 2 and 3, got %v", rows)
+	}
+}
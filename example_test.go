@@ -293,7 +293,7 @@ Meeting,2024-03-22 14:30:00,2024-03-22T16:00:00Z,90
 Webinar,2024-04-01T10:00:00Z,2024-04-01T11:30:00Z,90`
 
 	reader := strings.NewReader(csvData)
-	iterator, err := NewFromReader[Event](reader)
+	iterator, err := New[Event](FromReader(reader), WithDateOrder(MonthFirst))
 	if err != nil {
 		t.Fatalf("Failed to create iterator: %v", err)
 	}
@@ -353,7 +353,7 @@ Conference,2024-03-15,2024-03-15T18:00:00Z,480
 Workshop,03/20/2024 09:00:00,,240`
 
 	reader := strings.NewReader(csvData)
-	iterator, err := NewFromReader[Event](reader)
+	iterator, err := New[Event](FromReader(reader), WithDateOrder(MonthFirst))
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
@@ -1,3 +1,8 @@
 module github.com/ivikasavnish/supercsv-go
 
 go 1.25
+
+require (
+	github.com/klauspost/compress v1.18.0
+	github.com/ulikunitz/xz v0.5.15
+)
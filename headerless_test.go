@@ -0,0 +1,92 @@
+package supercsv
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type BankTransaction struct {
+	Date        time.Time `csv:"#0,required"`
+	Description string    `csv:"#1"`
+	Amount      float64   `csv:"amount,index=2,required"`
+	Balance     *float64  `csv:"#3"`
+}
+
+func TestCSVIterator_NoHeader(t *testing.T) {
+	csvData := `2024-03-01,Coffee,-3.50,996.50
+2024-03-02,Salary,2500.00,3496.50
+2024-03-03,Refund,12.00`
+
+	iterator, err := New[BankTransaction](FromReader(strings.NewReader(csvData)), WithNoHeader())
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	transactions, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read transactions: %v", err)
+	}
+
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+	if transactions[0].Description != "Coffee" || transactions[0].Amount != -3.5 {
+		t.Errorf("Unexpected first transaction: %+v", transactions[0])
+	}
+	if transactions[0].Balance == nil || *transactions[0].Balance != 996.5 {
+		t.Errorf("Expected balance 996.5, got %v", transactions[0].Balance)
+	}
+	if transactions[2].Balance != nil {
+		t.Errorf("Expected nil balance for short row, got %v", *transactions[2].Balance)
+	}
+	if iterator.Headers() != nil {
+		t.Errorf("Expected no headers, got %v", iterator.Headers())
+	}
+}
+
+func TestCSVIterator_MixedIndexAndName(t *testing.T) {
+	type Reading struct {
+		Sensor string  `csv:"#0"`
+		Value  float64 `csv:"value,required"`
+	}
+
+	csvData := `id,unit,value
+s-1,C,21.5`
+
+	iterator, err := NewFromReader[Reading](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	reading, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read reading: %v", err)
+	}
+	if reading.Sensor != "s-1" || reading.Value != 21.5 {
+		t.Errorf("Unexpected reading: %+v", reading)
+	}
+}
+
+func TestCSVIterator_NoHeaderNamedRequired(t *testing.T) {
+	_, err := New[Person](FromReader(strings.NewReader("John,30,john@example.com")), WithNoHeader())
+	if err == nil {
+		t.Fatal("Expected error for named required field without header")
+	}
+	if !strings.Contains(err.Error(), "cannot be matched without a header") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestCSVIterator_InvalidIndexTag(t *testing.T) {
+	type BadIndex struct {
+		Value string `csv:"#x"`
+	}
+
+	_, err := NewFromReader[BadIndex](strings.NewReader("a\n1"))
+	if err == nil || !strings.Contains(err.Error(), `invalid column index "x"`) {
+		t.Errorf("Expected invalid column index error, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package supercsv
+
+import (
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the remaining rows for use with range:
+//
+//	for person, err := range iterator.All() {
+//	    ...
+//	}
+//
+// Row errors are yielded alongside a nil item. Iteration continues past rows
+// that failed to parse, and stops after errors that cannot be recovered from.
+// Breaking out of the loop stops reading; with WithAutoClose the source is
+// closed when the loop ends for any reason.
+func (it *CSVIterator[T]) All() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		defer it.autoClose()
+
+		for {
+			item, err := it.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(item, err) {
+				return
+			}
+			if err != nil && !canContinue(err) {
+				return
+			}
+		}
+	}
+}
+
+// Rows returns an iterator over the remaining rows yielding the 1-based row
+// index and the decoded item. It stops at the first error, which is then
+// available from Err.
+func (it *CSVIterator[T]) Rows() iter.Seq2[int, *T] {
+	return func(yield func(int, *T) bool) {
+		defer it.autoClose()
+		it.err = nil
+
+		for {
+			item, err := it.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				it.err = err
+				return
+			}
+			if !yield(it.row, item) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error that stopped the last Rows iteration, if any
+func (it *CSVIterator[T]) Err() error {
+	return it.err
+}
+
+func (it *CSVIterator[T]) autoClose() {
+	if it.closeOnDone {
+		it.Close()
+	}
+}
+
+// canContinue reports whether iteration can proceed after an error from Next
+func canContinue(err error) bool {
+	perr, ok := err.(*ParseError)
+	return ok && isRecoverable(perr)
+}
+
+// Collect gathers all items from seq into a slice, stopping at the first error
+func Collect[V any](seq iter.Seq2[V, error]) ([]V, error) {
+	var results []V
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+// Filter returns the items of seq for which keep returns true.
+// Errors are passed through unchanged.
+func Filter[V any](seq iter.Seq2[V, error], keep func(V) bool) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for item, err := range seq {
+			if err == nil && !keep(item) {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// Map transforms each item of seq with fn. Errors from seq are passed through
+// and errors returned by fn are yielded in place of the item.
+func Map[V, U any](seq iter.Seq2[V, error], fn func(V) (U, error)) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		for item, err := range seq {
+			var mapped U
+			if err == nil {
+				mapped, err = fn(item)
+			}
+			if !yield(mapped, err) {
+				return
+			}
+		}
+	}
+}
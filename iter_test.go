@@ -0,0 +1,170 @@
+package supercsv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type trackingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func trackingSource(data string) (Source, *trackingReader) {
+	reader := &trackingReader{Reader: strings.NewReader(data)}
+	return Source{open: func(cfg *config) (io.Reader, io.Closer, error) {
+		return reader, reader, nil
+	}}, reader
+}
+
+const iterCSV = `name,age,email
+John Doe,30,john@example.com
+Jane Smith,abc,jane@example.com
+Bob Johnson,35,bob@example.com`
+
+func TestCSVIterator_All(t *testing.T) {
+	iterator, err := NewFromReader[Person](strings.NewReader(iterCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	var names []string
+	var errs []error
+	for person, err := range iterator.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, person.Name)
+	}
+
+	if strings.Join(names, ",") != "John Doe,Bob Johnson" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], strconv.ErrSyntax) {
+		t.Errorf("Expected one syntax error, got %v", errs)
+	}
+}
+
+func TestCSVIterator_AllBreakAutoClose(t *testing.T) {
+	src, reader := trackingSource(iterCSV)
+	iterator, err := New[Person](src, WithAutoClose())
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+
+	count := 0
+	for range iterator.All() {
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("Expected 1 iteration, got %d", count)
+	}
+	if !reader.closed {
+		t.Error("Expected source to be closed after break")
+	}
+	if err := iterator.Close(); err != nil {
+		t.Errorf("Expected repeated Close to succeed, got %v", err)
+	}
+}
+
+func TestCSVIterator_Rows(t *testing.T) {
+	src, reader := trackingSource(iterCSV)
+	iterator, err := New[Person](src)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	var rows []int
+	for row, person := range iterator.Rows() {
+		rows = append(rows, row)
+		if person.Name != "John Doe" {
+			t.Errorf("Expected John Doe, got %s", person.Name)
+		}
+	}
+
+	if len(rows) != 1 || rows[0] != 1 {
+		t.Errorf("Expected only row 1, got %v", rows)
+	}
+	var perr *ParseError
+	if !errors.As(iterator.Err(), &perr) || perr.Row != 2 {
+		t.Errorf("Expected ParseError on row 2, got %v", iterator.Err())
+	}
+	if reader.closed {
+		t.Error("Expected source to stay open without WithAutoClose")
+	}
+}
+
+func TestCollectFilterMap(t *testing.T) {
+	csvData := `name,age,email
+John Doe,30,john@example.com
+Jane Smith,25,jane@example.com
+Bob Johnson,35,bob@example.com`
+
+	iterator, err := NewFromReader[Person](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	adults := Filter(iterator.All(), func(p *Person) bool { return p.Age >= 30 })
+	emails := Map(adults, func(p *Person) (string, error) { return p.Email, nil })
+
+	result, err := Collect(emails)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(result, ",") != "john@example.com,bob@example.com" {
+		t.Errorf("Unexpected result: %v", result)
+	}
+}
+
+func TestCollect_Error(t *testing.T) {
+	iterator, err := NewFromReader[Person](strings.NewReader(iterCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	people, err := Collect(iterator.All())
+	if err == nil || people != nil {
+		t.Fatalf("Expected error and nil slice, got %v, %v", people, err)
+	}
+}
+
+func ExampleCSVIterator_All() {
+	csvData := `name,age,email
+John Doe,30,john@example.com
+Jane Smith,25,jane@example.com`
+
+	iterator, err := NewFromReader[Person](strings.NewReader(csvData))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer iterator.Close()
+
+	for person, err := range iterator.All() {
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		fmt.Printf("Name: %s, Age: %d\n", person.Name, person.Age)
+	}
+	// Output:
+	// Name: John Doe, Age: 30
+	// Name: Jane Smith, Age: 25
+}
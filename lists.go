@@ -0,0 +1,129 @@
+package supercsv
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// isRepeatedField reports whether a field is a slice or array of values.
+// Without a sep option such fields collect every column matching their name
+// or pattern. Types with their own unmarshaling (such as net.IP) are decoded
+// from a single cell.
+func isRepeatedField(fieldType reflect.Type) bool {
+	if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+		return false
+	}
+	if _, ok := lookupConverter(fieldType); ok {
+		return false
+	}
+	ptrType := reflect.PointerTo(fieldType)
+	return !ptrType.Implements(csvUnmarshalerType) && !ptrType.Implements(textUnmarshalerType)
+}
+
+// collectsColumns reports whether a field is a slice read from a variable
+// number of repeated columns rather than from one cell
+func (f *fieldInfo) collectsColumns() bool {
+	return f.fieldType.Kind() == reflect.Slice && f.sep == "" && isRepeatedField(f.fieldType)
+}
+
+// checkListTag validates the sep option and column patterns of a field
+func checkListTag(name string, fieldType reflect.Type, tag tagOptions) error {
+	if tag.sep != "" && !isRepeatedField(fieldType) {
+		return fmt.Errorf("field %s tagged sep must be a slice or array, got %s", name, fieldType)
+	}
+	if isColumnPattern(tag.column) || slices.ContainsFunc(tag.aliases, isColumnPattern) {
+		if !isRepeatedField(fieldType) || tag.sep != "" {
+			return fmt.Errorf("field %s with a column pattern must be a slice or array without sep, got %s", name, fieldType)
+		}
+	}
+	return nil
+}
+
+// isColumnPattern reports whether a column name contains a * wildcard
+func isColumnPattern(name string) bool {
+	return strings.Contains(name, "*")
+}
+
+// matchColumns returns the positions of every header matching pattern, in
+// header order. Each * in the pattern matches any run of characters.
+func matchColumns(pattern string, headers []string, cfg *config) []int {
+	parts := strings.Split(cfg.normalizeHeader(pattern), "*")
+
+	var matches []int
+	for i, header := range headers {
+		if matchWildcard(parts, cfg.normalizeHeader(strings.TrimSpace(header))) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// matchWildcard matches s against a pattern already split on its wildcards
+func matchWildcard(parts []string, s string) bool {
+	rest, ok := strings.CutPrefix(s, parts[0])
+	if !ok {
+		return false
+	}
+	last := len(parts) - 1
+	for _, part := range parts[1:last] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	return last == 0 && rest == "" || last > 0 && strings.HasSuffix(rest, parts[last])
+}
+
+// patternColumn names the i-th column written for a repeated field, replacing
+// the * of a pattern with the 1-based position
+func patternColumn(column string, i int) string {
+	return strings.Replace(column, "*", strconv.Itoa(i+1), 1)
+}
+
+// setList splits strValue on the field's separator and parses each non-empty
+// part into an element of the slice or array
+func setList(fieldValue reflect.Value, strValue string, fieldType reflect.Type, field *fieldInfo) error {
+	var parts []string
+	for _, part := range strings.Split(strValue, field.sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	list := fieldValue
+	if fieldType.Kind() == reflect.Slice {
+		list = reflect.MakeSlice(fieldType, len(parts), len(parts))
+	} else if len(parts) > fieldType.Len() {
+		return fmt.Errorf("%d values do not fit in %s", len(parts), fieldType)
+	}
+
+	elemType := fieldType.Elem()
+	for i, part := range parts {
+		if err := setFieldValue(list.Index(i), part, elemType, field); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	if fieldType.Kind() == reflect.Slice {
+		fieldValue.Set(list)
+	}
+	return nil
+}
+
+// formatList joins the formatted elements of a slice or array with the
+// field's separator
+func formatList(fieldValue reflect.Value, fieldType reflect.Type, field *fieldInfo) (string, error) {
+	parts := make([]string, fieldValue.Len())
+	for i := range parts {
+		part, err := formatFieldValue(fieldValue.Index(i), fieldType.Elem(), field)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, field.sep), nil
+}
@@ -0,0 +1,150 @@
+package supercsv
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type Scorecard struct {
+	Player string   `csv:"player"`
+	Tags   []string `csv:"tags,sep=|"`
+	Scores []int    `csv:"score_*"`
+	Best   [2]int   `csv:"best,sep=;"`
+	Rounds [3]int   `csv:"round_*"`
+}
+
+const scorecardCSV = `player,tags,score_1,round_1,score_2,round_2,score_3,best,round_3
+ann,pro|left,10,1,,2,30,9;8,3
+ben,,5,,6,,,,`
+
+func TestListFields(t *testing.T) {
+	iterator, err := New[Scorecard](FromReader(strings.NewReader(scorecardCSV)))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	cards, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read scorecards: %v", err)
+	}
+
+	ann := cards[0]
+	if strings.Join(ann.Tags, ",") != "pro,left" {
+		t.Errorf("Expected tags [pro left], got %v", ann.Tags)
+	}
+	if len(ann.Scores) != 2 || ann.Scores[0] != 10 || ann.Scores[1] != 30 {
+		t.Errorf("Expected scores [10 30], got %v", ann.Scores)
+	}
+	if ann.Best != [2]int{9, 8} || ann.Rounds != [3]int{1, 2, 3} {
+		t.Errorf("Unexpected arrays: best %v, rounds %v", ann.Best, ann.Rounds)
+	}
+
+	ben := cards[1]
+	if ben.Tags != nil || ben.Rounds != [3]int{} {
+		t.Errorf("Expected empty cells to leave zero values, got %+v", ben)
+	}
+	if len(ben.Scores) != 2 || ben.Scores[1] != 6 {
+		t.Errorf("Expected scores [5 6], got %v", ben.Scores)
+	}
+}
+
+func TestListFieldErrors(t *testing.T) {
+	t.Run("too many values for array", func(t *testing.T) {
+		type Pair struct {
+			Values [2]int `csv:"values,sep=|"`
+		}
+		iterator, err := New[Pair](FromReader(strings.NewReader("values\n1|2|3")))
+		if err != nil {
+			t.Fatalf("Failed to create iterator: %v", err)
+		}
+		defer iterator.Close()
+
+		_, err = iterator.Next()
+		if err == nil || !strings.Contains(err.Error(), "3 values do not fit in [2]int") {
+			t.Errorf("Expected overflow error, got %v", err)
+		}
+	})
+
+	t.Run("bad element names the column", func(t *testing.T) {
+		iterator, err := New[Scorecard](FromReader(strings.NewReader("player,score_1,score_2\nann,1,x")))
+		if err != nil {
+			t.Fatalf("Failed to create iterator: %v", err)
+		}
+		defer iterator.Close()
+
+		_, err = iterator.Next()
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Header != "score_2" || perr.Field != "Scores" {
+			t.Errorf("Expected parse error for score_2, got %v", err)
+		}
+	})
+
+	t.Run("more columns than array length", func(t *testing.T) {
+		_, err := New[Scorecard](FromReader(strings.NewReader("round_1,round_2,round_3,round_4\n1,2,3,4")))
+		if err == nil || !strings.Contains(err.Error(), "holds 3 values but 4 columns match") {
+			t.Errorf("Expected construction error, got %v", err)
+		}
+	})
+
+	t.Run("sep on scalar", func(t *testing.T) {
+		type Bad struct {
+			Name string `csv:"name,sep=|"`
+		}
+		_, err := New[Bad](FromReader(strings.NewReader("name\nx")))
+		if err == nil || !strings.Contains(err.Error(), "tagged sep must be a slice or array") {
+			t.Errorf("Expected tag error, got %v", err)
+		}
+	})
+}
+
+func TestWriterListFields(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Scorecard](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	cards := []*Scorecard{
+		{Player: "ann", Tags: []string{"pro", "left"}, Scores: []int{10, 20, 30}, Best: [2]int{9, 8}, Rounds: [3]int{1, 2, 3}},
+		{Player: "ben", Scores: []int{5}},
+	}
+	if err := writer.WriteAll(cards); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	expected := "player,tags,best,round_1,round_2,round_3,score_1,score_2,score_3\n" +
+		"ann,pro|left,9;8,1,2,3,10,20,30\n" +
+		"ben,,0;0,0,0,0,5,,\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	// The written file reads back into the same scores
+	iterator, err := New[Scorecard](FromReader(strings.NewReader(buf.String())))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	read, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read back: %v", err)
+	}
+	if len(read) != 2 || !slices.Equal(read[0].Scores, cards[0].Scores) || !slices.Equal(read[1].Scores, cards[1].Scores) {
+		t.Errorf("Expected scores to round-trip, got %+v", read)
+	}
+
+	// Later rows cannot add columns to a header already written
+	buf.Reset()
+	writer, err = NewWriterToWriter[Scorecard](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	err = writer.WriteAll([]*Scorecard{{Scores: []int{1}}, {Scores: []int{1, 2}}})
+	if err == nil || !strings.Contains(err.Error(), "field Scores has 2 values but the first written row's header has 1 columns") {
+		t.Errorf("Expected too many values error, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package supercsv
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// structField is a tagged leaf field found while flattening a struct
+type structField struct {
+	name  string // Dotted path such as "Billing.Street"
+	index []int  // Index path for reflect.Value.FieldByIndex
+	typ   reflect.Type
+	tag   tagOptions
+}
+
+// walkFields flattens structType into its tagged leaf fields. Embedded structs
+// without a csv tag are descended into as if their fields were declared inline,
+// as are struct fields tagged prefix or inline. A prefix tag's column name is
+// prepended to every column of the nested struct.
+func walkFields(structType reflect.Type) ([]structField, error) {
+	return appendFields(nil, structType, "", "", nil, map[reflect.Type]bool{})
+}
+
+func appendFields(fields []structField, structType reflect.Type, prefix, path string, index []int, visiting map[reflect.Type]bool) ([]structField, error) {
+	if visiting[structType] {
+		return nil, fmt.Errorf("struct %s contains itself", structType)
+	}
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := path + field.Name
+		fieldIndex := append(slices.Clip(index), i)
+
+		csvTag := field.Tag.Get("csv")
+
+		// Embedded structs are flattened; an unexported embedded struct still
+		// promotes its exported fields unless it is behind a pointer
+		if field.Anonymous && csvTag == "" && isNestedStruct(field.Type) {
+			if !field.IsExported() && field.Type.Kind() == reflect.Ptr {
+				continue
+			}
+			var err error
+			if fields, err = appendFields(fields, indirectType(field.Type), prefix, name+".", fieldIndex, visiting); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Skip unexported fields
+		if !field.IsExported() {
+			continue
+		}
+
+		if csvTag == "" {
+			return nil, fmt.Errorf("field %s missing required 'csv' annotation", name)
+		}
+
+		tag, err := parseCSVTag(csvTag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+
+		if tag.prefix || tag.inline {
+			if !isNestedStruct(field.Type) {
+				return nil, fmt.Errorf("field %s tagged prefix or inline must be a struct, got %s", name, field.Type)
+			}
+			nestedPrefix := prefix
+			if tag.prefix {
+				nestedPrefix += tag.column
+			}
+			if fields, err = appendFields(fields, indirectType(field.Type), nestedPrefix, name+".", fieldIndex, visiting); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := checkListTag(name, field.Type, tag); err != nil {
+			return nil, err
+		}
+		if err := checkNumberTag(name, field.Type, tag); err != nil {
+			return nil, err
+		}
+		if err := checkBoolTag(name, field.Type, tag); err != nil {
+			return nil, err
+		}
+		if err := checkUnit(name, field.Type, tag); err != nil {
+			return nil, err
+		}
+
+		if tag.column != "" {
+			tag.column = prefix + tag.column
+		}
+		for j, alias := range tag.aliases {
+			tag.aliases[j] = prefix + alias
+		}
+
+		fields = append(fields, structField{name: name, index: fieldIndex, typ: field.Type, tag: tag})
+	}
+
+	return fields, nil
+}
+
+// isNestedStruct reports whether t (or what it points to) is a struct whose
+// fields map to columns, rather than a value decoded from a single cell
+func isNestedStruct(t reflect.Type) bool {
+	if _, ok := lookupConverter(t); ok {
+		return false
+	}
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	if _, ok := lookupConverter(t); ok {
+		return false
+	}
+	ptrType := reflect.PointerTo(t)
+	return !ptrType.Implements(csvUnmarshalerType) && !ptrType.Implements(textUnmarshalerType)
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// fieldByIndexAlloc returns the nested field at index, allocating any nil
+// embedded or nested struct pointers along the way
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldByIndex returns the nested field at index, or an invalid Value if a
+// nested struct pointer along the way is nil
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+	field, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return field
+}
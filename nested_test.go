@@ -0,0 +1,138 @@
+package supercsv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type Address struct {
+	Street string `csv:"street"`
+	City   string `csv:"city,aliases=town"`
+}
+
+type Audit struct {
+	CreatedBy string `csv:"created_by"`
+}
+
+type Order struct {
+	Audit
+	ID       int      `csv:"id"`
+	Billing  Address  `csv:"billing_,prefix"`
+	Shipping *Address `csv:"shipping_,prefix"`
+	Contact  struct {
+		Email string `csv:"email"`
+	} `csv:",inline"`
+}
+
+const orderCSV = `id,created_by,billing_street,billing_town,shipping_street,shipping_city,email
+1,alice,1 Main St,Springfield,9 Dock Rd,Portsmouth,a@example.com
+2,bob,2 High St,Shelbyville,,,b@example.com`
+
+func TestNestedStructs(t *testing.T) {
+	iterator, err := New[Order](FromReader(strings.NewReader(orderCSV)))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	orders, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read orders: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(orders))
+	}
+
+	first := orders[0]
+	if first.ID != 1 || first.CreatedBy != "alice" || first.Contact.Email != "a@example.com" {
+		t.Errorf("Unexpected first order: %+v", first)
+	}
+	if first.Billing != (Address{Street: "1 Main St", City: "Springfield"}) {
+		t.Errorf("Unexpected billing address: %+v", first.Billing)
+	}
+	if first.Shipping == nil || *first.Shipping != (Address{Street: "9 Dock Rd", City: "Portsmouth"}) {
+		t.Errorf("Unexpected shipping address: %+v", first.Shipping)
+	}
+
+	if orders[1].Shipping != nil {
+		t.Errorf("Expected nil shipping address for empty columns, got %+v", orders[1].Shipping)
+	}
+	if len(iterator.UnmappedColumns()) != 0 {
+		t.Errorf("Expected all columns mapped, got %v", iterator.UnmappedColumns())
+	}
+}
+
+func TestNestedStructParseErrorNamesPath(t *testing.T) {
+	type Line struct {
+		Item struct {
+			Qty int `csv:"qty"`
+		} `csv:"item_,prefix"`
+	}
+
+	iterator, err := New[Line](FromReader(strings.NewReader("item_qty\nmany")))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.Next()
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("Expected *ParseError, got %v", err)
+	}
+	if perr.Field != "Item.Qty" || perr.Header != "item_qty" {
+		t.Errorf("Unexpected field %q and header %q", perr.Field, perr.Header)
+	}
+}
+
+func TestNestedStructErrors(t *testing.T) {
+	type NotStruct struct {
+		Name string `csv:"name_,prefix"`
+	}
+	if _, err := New[NotStruct](FromReader(strings.NewReader("name_\nx"))); err == nil || !strings.Contains(err.Error(), "must be a struct") {
+		t.Errorf("Expected prefix on non-struct to fail, got %v", err)
+	}
+
+	type Untagged struct {
+		Billing Address
+	}
+	if _, err := New[Untagged](FromReader(strings.NewReader("street\nx"))); err == nil || !strings.Contains(err.Error(), "field Billing missing") {
+		t.Errorf("Expected untagged struct field to fail, got %v", err)
+	}
+}
+
+func TestWriterNestedStructs(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Order](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	order := &Order{ID: 1, Billing: Address{Street: "1 Main St", City: "Springfield"}}
+	order.CreatedBy = "alice"
+	order.Contact.Email = "a@example.com"
+	if err := writer.WriteAll([]*Order{order}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	expected := "created_by,id,billing_street,billing_city,shipping_street,shipping_city,email\n" +
+		"alice,1,1 Main St,Springfield,,,a@example.com\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	iterator, err := NewFromReader[Order](strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	decoded, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read back: %v", err)
+	}
+	if decoded.Billing != order.Billing || decoded.Shipping != nil || decoded.CreatedBy != "alice" {
+		t.Errorf("Round trip mismatch: %+v", decoded)
+	}
+}
@@ -0,0 +1,94 @@
+package supercsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type WarehouseRow struct {
+	ID      int      `csv:"id,required"`
+	Qty     *int     `csv:"qty"`
+	Price   float64  `csv:"price"`
+	Country string   `csv:"country,null=-"` // NA is Namibia here
+	Tags    []string `csv:"tag"`
+}
+
+func TestNullValues(t *testing.T) {
+	csvData := `id,qty,price,country,tag,tag
+1,NULL,\N,NA,x,NULL
+2,5,9.5,-,NA,y`
+
+	iterator, err := New[WarehouseRow](FromReader(strings.NewReader(csvData)), WithNullValues("NULL", `\N`, "NA"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	rows, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read rows: %v", err)
+	}
+
+	first := rows[0]
+	if first.Qty != nil || first.Price != 0 || first.Country != "NA" {
+		t.Errorf("Unexpected first row: %+v", first)
+	}
+	if strings.Join(first.Tags, ",") != "x" {
+		t.Errorf("Expected null tag to be skipped, got %v", first.Tags)
+	}
+
+	second := rows[1]
+	if second.Qty == nil || *second.Qty != 5 || second.Country != "" {
+		t.Errorf("Unexpected second row: %+v", second)
+	}
+	if strings.Join(second.Tags, ",") != "y" {
+		t.Errorf("Expected null tag to be skipped, got %v", second.Tags)
+	}
+}
+
+func TestNullValueInRequiredField(t *testing.T) {
+	iterator, err := New[WarehouseRow](FromReader(strings.NewReader("id,qty\nNULL,1")), WithNullValues("NULL"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.Next()
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Field != "ID" || !strings.Contains(err.Error(), "null value in required column 'id'") {
+		t.Errorf("Expected required null error, got %v", err)
+	}
+}
+
+func TestWriterNullToken(t *testing.T) {
+	type Reading struct {
+		Value *float64 `csv:"value"`
+		Note  *string  `csv:"note,null=n/a"`
+	}
+	var buf bytes.Buffer
+	readingWriter, err := NewWriterToWriter[Reading](&buf, WithNullValues(`\N`))
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := readingWriter.WriteAll([]*Reading{{}}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if buf.String() != "value,note\n\\N,n/a\n" {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+
+	// Fields of a nil nested struct are null as well
+	buf.Reset()
+	writer, err := NewWriterToWriter[Order](&buf, WithNullValues(`\N`, "NULL"))
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Order{{ID: 1}}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\n,1,,,\\N,\\N,\n") {
+		t.Errorf("Expected null shipping columns, got:\n%s", buf.String())
+	}
+}
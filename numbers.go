@@ -0,0 +1,315 @@
+package supercsv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// numberLocale holds the separators a locale uses when writing numbers
+type numberLocale struct {
+	thousands string
+	decimal   string
+}
+
+var numberLocales = map[string]numberLocale{
+	"en":    {thousands: ",", decimal: "."},
+	"de":    {thousands: ".", decimal: ","},
+	"de-ch": {thousands: "'", decimal: "."},
+	"es":    {thousands: ".", decimal: ","},
+	"fr":    {thousands: " ", decimal: ","},
+	"it":    {thousands: ".", decimal: ","},
+	"nl":    {thousands: ".", decimal: ","},
+	"pl":    {thousands: " ", decimal: ","},
+	"pt":    {thousands: ".", decimal: ","},
+	"ru":    {thousands: " ", decimal: ","},
+	"sv":    {thousands: " ", decimal: ","},
+}
+
+// lookupLocale finds a locale by tag such as "de", "de-DE" or "de_CH",
+// falling back from the region to the language
+func lookupLocale(name string) (numberLocale, bool) {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	if locale, ok := numberLocales[name]; ok {
+		return locale, true
+	}
+	language, _, _ := strings.Cut(name, "-")
+	locale, ok := numberLocales[language]
+	return locale, ok
+}
+
+// numberFormat describes how a numeric field is written in the file
+type numberFormat struct {
+	thousands string
+	decimal   string
+	currency  bool   // Currency symbols around the number are ignored when reading
+	symbol    string // Currency symbol written before the number
+	percent   bool   // "45%" holds 0.45
+	// ungrouped numbers are written without thousands separators, though
+	// grouped ones are still read
+	ungrouped bool
+}
+
+// newNumberFormat combines a field's number options with the default locale.
+// It returns nil when the field's numbers are plain strconv syntax. Integers
+// following the default locale are not grouped when written, so years and IDs
+// stay as they are; a locale= or thousands= option on the field groups them.
+func newNumberFormat(tag tagOptions, defaultLocale string, fieldType reflect.Type) *numberFormat {
+	name := tag.locale
+	ungrouped := false
+	if name == "" && tag.thousands == "" && tag.decimal == "" {
+		name = defaultLocale
+		ungrouped = isIntegerType(fieldType)
+	}
+	if name == "" && tag.thousands == "" && tag.decimal == "" && !tag.currency && !tag.percent {
+		return nil
+	}
+
+	format := &numberFormat{
+		decimal:   ".",
+		currency:  tag.currency,
+		symbol:    tag.currencySymbol,
+		percent:   tag.percent,
+		ungrouped: ungrouped,
+	}
+	if locale, ok := lookupLocale(name); ok {
+		format.thousands, format.decimal = locale.thousands, locale.decimal
+	}
+	if tag.thousands != "" {
+		format.thousands = tag.thousands
+	}
+	if tag.decimal != "" {
+		format.decimal = tag.decimal
+	}
+	return format
+}
+
+// isIntegerType reports whether a field, or the elements of a list field,
+// holds integers
+func isIntegerType(fieldType reflect.Type) bool {
+	for fieldType.Kind() == reflect.Ptr || isRepeatedField(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fieldType != durationType
+	}
+	return false
+}
+
+// checkNumberTag validates that number options are only used on numeric fields
+func checkNumberTag(name string, fieldType reflect.Type, tag tagOptions) error {
+	if tag.locale == "" && tag.thousands == "" && tag.decimal == "" && !tag.currency && !tag.percent && tag.base == 10 {
+		return nil
+	}
+
+	for fieldType.Kind() == reflect.Ptr || isRepeatedField(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if tag.base != 10 {
+			return fmt.Errorf("field %s tagged base must be an integer, got %s", name, fieldType)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if tag.percent {
+			return fmt.Errorf("field %s tagged percent must be a float, got %s", name, fieldType)
+		}
+		return nil
+	}
+	return fmt.Errorf("field %s has number options but is not numeric, got %s", name, fieldType)
+}
+
+// numberError wraps a strconv error, reporting values out of the field
+// type's range as *OverflowError
+func numberError(kind, value string, fieldType reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return &OverflowError{Value: value, Type: fieldType, Err: err}
+	}
+	return fmt.Errorf("invalid %s: %w", kind, err)
+}
+
+// trimBasePrefix strips the 0x, 0o or 0b prefix matching an explicit base,
+// which strconv only accepts with base 0. A sign after the prefix, as in
+// "0x-5", is left in place so that parsing rejects it like base 0 does.
+func trimBasePrefix(s string, base int) string {
+	var prefix string
+	switch base {
+	case 2:
+		prefix = "0b"
+	case 8:
+		prefix = "0o"
+	case 16:
+		prefix = "0x"
+	default:
+		return s
+	}
+
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	if len(s) > 2 && strings.EqualFold(s[:2], prefix) && s[2] != '-' && s[2] != '+' {
+		s = s[2:]
+	}
+	return sign + s
+}
+
+// formatBase is the base integers are written in; auto-detected fields are
+// written in decimal
+func formatBase(base int) int {
+	if base == 0 {
+		return 10
+	}
+	return base
+}
+
+// normalize rewrites a formatted number into strconv syntax. A nil format
+// returns the value unchanged.
+func (f *numberFormat) normalize(value string) (string, error) {
+	if f == nil {
+		return value, nil
+	}
+
+	s := strings.TrimSpace(value)
+	sign := ""
+	if f.currency {
+		// "-$5", "$-5" and "5 €" are all accepted
+		s, sign = trimSign(strings.TrimFunc(s, isCurrencyOrSpace))
+		s = strings.TrimFunc(s, isCurrencyOrSpace)
+	}
+	if f.percent {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	}
+	s, numberSign := trimSign(s)
+	if numberSign != "" {
+		sign = numberSign
+	}
+
+	if f.thousands == " " {
+		// Spreadsheets often group with no-break spaces instead
+		s = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, f.decimal)
+	if f.thousands != "" && strings.Contains(integer, f.thousands) {
+		groups := strings.Split(integer, f.thousands)
+		for i, group := range groups {
+			if len(group) != 3 && (i > 0 || len(group) == 0 || len(group) > 3) {
+				return "", fmt.Errorf("misplaced thousands separator in number %q", value)
+			}
+		}
+		integer = strings.Join(groups, "")
+	}
+	if f.decimal != "." && strings.Contains(integer, ".") {
+		return "", fmt.Errorf("unexpected '.' in number %q", value)
+	}
+
+	s = integer
+	if hasFraction {
+		s += "." + fraction
+	}
+	s = sign + s
+	if f.percent {
+		s += "e-2"
+	}
+	return s, nil
+}
+
+func trimSign(s string) (string, string) {
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return strings.TrimSpace(rest), "-"
+	}
+	return strings.TrimPrefix(s, "+"), ""
+}
+
+func isCurrencyOrSpace(r rune) bool {
+	return unicode.Is(unicode.Sc, r) || unicode.IsSpace(r)
+}
+
+// format writes a number given in strconv syntax, such as "-1234.5", in the
+// field's format. A nil format returns the value unchanged.
+func (f *numberFormat) format(s string) string {
+	if f == nil {
+		return s
+	}
+
+	sign := ""
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = "-", rest
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	out := integer
+	if !f.ungrouped {
+		out = groupThousands(integer, f.thousands)
+	}
+	if hasFraction {
+		out += f.decimal + fraction
+	}
+	if f.percent {
+		out += "%"
+	}
+	return sign + f.symbol + out
+}
+
+// groupThousands inserts sep between every three digits of an integer
+func groupThousands(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// formatFloat formats v in plain decimal notation, scaled by 10^shift, without
+// the rounding error that multiplying first would introduce
+func formatFloat(v float64, bits, shift int) string {
+	if shift == 0 {
+		return strconv.FormatFloat(v, 'f', -1, bits)
+	}
+
+	s := strconv.FormatFloat(v, 'e', -1, bits)
+	mantissa, exponent, ok := strings.Cut(s, "e")
+	if !ok {
+		return s // NaN and infinities
+	}
+	exp, _ := strconv.Atoi(exponent)
+
+	sign := ""
+	if rest, ok := strings.CutPrefix(mantissa, "-"); ok {
+		sign, mantissa = "-", rest
+	}
+	digits := strings.Replace(mantissa, ".", "", 1)
+	if digits == "0" {
+		return "0"
+	}
+
+	// digits[:point] is the integer part
+	point := exp + shift + 1
+	switch {
+	case point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits
+	case point >= len(digits):
+		return sign + digits + strings.Repeat("0", point-len(digits))
+	default:
+		return sign + digits[:point] + "." + digits[point:]
+	}
+}
@@ -0,0 +1,314 @@
+package supercsv
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type LedgerEntry struct {
+	Amount   float64 `csv:"amount,locale=en,currency=$"`
+	Rate     float64 `csv:"rate,percent"`
+	Quantity int     `csv:"quantity,thousands=,"`
+	Price    float64 `csv:"price,thousands=.,decimal=,"`
+}
+
+func TestLocaleNumbers(t *testing.T) {
+	csvData := `amount,rate,quantity,price
+"$1,234.56",45%,"12,000","1.234,5"
+-$12.00,0.5%,7,"0,25"`
+
+	iterator, err := NewFromReader[LedgerEntry](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	entries, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read entries: %v", err)
+	}
+
+	expected := []LedgerEntry{
+		{Amount: 1234.56, Rate: 0.45, Quantity: 12000, Price: 1234.5},
+		{Amount: -12, Rate: 0.005, Quantity: 7, Price: 0.25},
+	}
+	for i, entry := range entries {
+		if *entry != expected[i] {
+			t.Errorf("Row %d: expected %+v, got %+v", i+1, expected[i], *entry)
+		}
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	type Reading struct {
+		Value float64 `csv:"value"`
+		Count uint    `csv:"count"`
+		Plain float64 `csv:"plain,locale=en"`
+	}
+
+	iterator, err := New[Reading](FromReader(strings.NewReader("value;count;plain\n1.234,5;1.000;2,000.5")), WithDelimiter(';'), WithLocale("de-DE"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	reading, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read: %v", err)
+	}
+	if reading.Value != 1234.5 || reading.Count != 1000 || reading.Plain != 2000.5 {
+		t.Errorf("Unexpected reading: %+v", reading)
+	}
+
+	if _, err := New[Reading](FromReader(strings.NewReader("value\n1")), WithLocale("xx")); err == nil || !strings.Contains(err.Error(), `unknown locale "xx"`) {
+		t.Errorf("Expected unknown locale error, got %v", err)
+	}
+}
+
+func TestLocaleNumberErrors(t *testing.T) {
+	type German struct {
+		Price float64 `csv:"price,locale=de"`
+	}
+	iterator, err := NewFromReader[German](strings.NewReader("price\n\"1,234.5\""))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+	if _, err := iterator.Next(); err == nil {
+		t.Error("Expected error for 1,234.5 read as German number")
+	}
+
+	type Grouped struct {
+		Count int `csv:"count,locale=en"`
+	}
+	grouped, err := NewFromReader[Grouped](strings.NewReader("count\n\"12,34\""))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer grouped.Close()
+	if _, err := grouped.Next(); err == nil || !strings.Contains(err.Error(), "misplaced thousands separator") {
+		t.Errorf("Expected grouping error, got %v", err)
+	}
+
+	type NotNumeric struct {
+		Name string `csv:"name,percent"`
+	}
+	if _, err := NewFromReader[NotNumeric](strings.NewReader("name\nx")); err == nil || !strings.Contains(err.Error(), "not numeric") {
+		t.Errorf("Expected number option error, got %v", err)
+	}
+
+	type IntPercent struct {
+		Share int `csv:"share,percent"`
+	}
+	if _, err := NewFromReader[IntPercent](strings.NewReader("share\n1")); err == nil || !strings.Contains(err.Error(), "must be a float") {
+		t.Errorf("Expected percent error, got %v", err)
+	}
+
+	type EmptyDecimal struct {
+		Rate float64 `csv:"rate,decimal=,percent"`
+	}
+	if _, err := NewFromReader[EmptyDecimal](strings.NewReader("rate\n\"45,5%\"")); err == nil || !strings.Contains(err.Error(), "decimal option needs a separator") {
+		t.Errorf("Expected empty decimal error, got %v", err)
+	}
+	if _, err := parseCSVTag("count,thousands="); err == nil {
+		t.Error("Expected empty thousands= to be rejected")
+	}
+	if opts, err := parseCSVTag("count,thousands=,"); err != nil || opts.thousands != "," {
+		t.Errorf("Expected thousands=, to set a comma, got %q, %v", opts.thousands, err)
+	}
+}
+
+func TestWriterLocaleNumbers(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[LedgerEntry](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	entries := []*LedgerEntry{
+		{Amount: 1234567.5, Rate: 0.07, Quantity: 12000, Price: 1234.5},
+		{Amount: -12, Rate: 1.125, Quantity: 999, Price: 0.25},
+	}
+	if err := writer.WriteAll(entries); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	expected := "amount,rate,quantity,price\n" +
+		"\"$1,234,567.5\",7%,\"12,000\",\"1.234,5\"\n" +
+		"-$12,112.5%,999,\"0,25\"\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	// And back again
+	iterator, err := NewFromReader[LedgerEntry](strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	for i, want := range entries {
+		got, err := iterator.Next()
+		if err != nil {
+			t.Fatalf("Failed to read back row %d: %v", i+1, err)
+		}
+		if *got != *want {
+			t.Errorf("Row %d: expected %+v, got %+v", i+1, *want, *got)
+		}
+	}
+}
+
+func TestWriterWithLocale(t *testing.T) {
+	type Report struct {
+		Year   int     `csv:"year"`
+		ID     uint64  `csv:"id"`
+		Amount float64 `csv:"amount"`
+		Count  int     `csv:"count,locale=en"`
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Report](&buf, WithLocale("en"))
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Report{{Year: 2024, ID: 1234567, Amount: 1234.5, Count: 12000}}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	// Only floats and integers with their own locale are grouped
+	expected := "year,id,amount,count\n2024,1234567,\"1,234.5\",\"12,000\"\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	// Grouped integers are still read under the iterator locale
+	iterator, err := New[Report](FromReader(strings.NewReader("year,id,amount,count\n\"2,024\",1,2,3")), WithLocale("en"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+	report, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read: %v", err)
+	}
+	if report.Year != 2024 {
+		t.Errorf("Expected year 2024, got %d", report.Year)
+	}
+}
+
+func TestFormatFloatShift(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0.45, "45"},
+		{0.07, "7"},
+		{0.005, "0.5"},
+		{1.125, "112.5"},
+		{-0.0001, "-0.01"},
+		{12, "1200"},
+		{0, "0"},
+	}
+	for _, tt := range tests {
+		if got := formatFloat(tt.value, 64, 2); got != tt.expected {
+			t.Errorf("formatFloat(%v, 64, 2) = %q, want %q", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	type Sizes struct {
+		Small int8    `csv:"small"`
+		Byte  uint8   `csv:"byte"`
+		Ratio float32 `csv:"ratio"`
+	}
+
+	tests := []struct {
+		csv   string
+		field string
+	}{
+		{"small,byte,ratio\n300,1,1", "Small"},
+		{"small,byte,ratio\n-129,1,1", "Small"},
+		{"small,byte,ratio\n1,256,1", "Byte"},
+		{"small,byte,ratio\n1,1,1e40", "Ratio"},
+	}
+
+	for _, tt := range tests {
+		iterator, err := NewFromReader[Sizes](strings.NewReader(tt.csv))
+		if err != nil {
+			t.Fatalf("Failed to create iterator: %v", err)
+		}
+
+		_, err = iterator.Next()
+		iterator.Close()
+
+		var overflow *OverflowError
+		var perr *ParseError
+		if !errors.As(err, &overflow) || !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("%q: expected *OverflowError, got %v", tt.csv, err)
+			continue
+		}
+		if errors.As(err, &perr) && perr.Field != tt.field {
+			t.Errorf("%q: expected field %s, got %s", tt.csv, tt.field, perr.Field)
+		}
+	}
+}
+
+func TestIntegerBase(t *testing.T) {
+	type Device struct {
+		ID    uint32 `csv:"id,base=16"`
+		Mask  uint8  `csv:"mask,base=0"`
+		Delta int16  `csv:"delta,base=16"`
+	}
+
+	iterator, err := NewFromReader[Device](strings.NewReader("id,mask,delta\n0xDEADbeef,0b1010,-0x10\n1f,0o17,7f"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	devices, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read devices: %v", err)
+	}
+
+	expected := []Device{{ID: 0xdeadbeef, Mask: 10, Delta: -16}, {ID: 0x1f, Mask: 15, Delta: 0x7f}}
+	for i, device := range devices {
+		if *device != expected[i] {
+			t.Errorf("Row %d: expected %+v, got %+v", i+1, expected[i], *device)
+		}
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Device](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Device{&expected[0]}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if buf.String() != "id,mask,delta\ndeadbeef,10,-10\n" {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+
+	for _, value := range []string{"0x-5", "0x+5", "-0x-5"} {
+		signed, err := NewFromReader[Device](strings.NewReader("delta\n" + value))
+		if err != nil {
+			t.Fatalf("Failed to create iterator: %v", err)
+		}
+		if _, err := signed.Next(); err == nil {
+			t.Errorf("Expected sign after prefix in %q to be rejected", value)
+		}
+		signed.Close()
+	}
+
+	type BadBase struct {
+		Value float64 `csv:"value,base=16"`
+	}
+	if _, err := NewFromReader[BadBase](strings.NewReader("value\n1")); err == nil || !strings.Contains(err.Error(), "tagged base must be an integer") {
+		t.Errorf("Expected base error, got %v", err)
+	}
+}
@@ -0,0 +1,290 @@
+package supercsv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Option configures a CSVIterator created with New
+type Option func(*config)
+
+type config struct {
+	delimiter        rune
+	comment          rune
+	lazyQuotes       bool
+	trimLeadingSpace bool
+	fieldsPerRecord  int
+	headerRow        int
+	skipRows         int
+	timeFormats      []string
+	dateOrder        DateOrder
+	location         *time.Location
+	errorPolicy      ErrorPolicy
+	rejects          io.Writer
+	autoClose        bool
+	ctx              context.Context
+	compression      Compression
+	encoding         Encoding
+	noHeader         bool
+	normalizeHeader  func(string) string
+	duplicatePolicy  DuplicatePolicy
+	strictColumns    bool
+	allowedColumns   []string
+	nullValues       []string
+	locale           string
+	trueValues       []string
+	falseValues      []string
+	rowValidator     any // func(*T) error, checked against the iterator's T in New
+}
+
+func defaultConfig() *config {
+	return &config{
+		delimiter:       ',',
+		fieldsPerRecord: -1, // Allow variable number of fields
+		location:        time.UTC,
+		normalizeHeader: func(s string) string { return s },
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// validate reports option values that cannot be used
+func (c *config) validate() error {
+	if c.locale != "" {
+		if _, ok := lookupLocale(c.locale); !ok {
+			return fmt.Errorf("unknown locale %q", c.locale)
+		}
+	}
+	if c.trueValues != nil || c.falseValues != nil {
+		if err := checkBoolValues(boolValues(tagOptions{}, c)); err != nil {
+			return fmt.Errorf("invalid WithBoolValues: %w", err)
+		}
+	}
+	return nil
+}
+
+// WithDelimiter sets the field delimiter (default ',')
+func WithDelimiter(delimiter rune) Option {
+	return func(c *config) {
+		c.delimiter = delimiter
+	}
+}
+
+// WithComment sets the comment character; lines beginning with it are ignored
+func WithComment(comment rune) Option {
+	return func(c *config) {
+		c.comment = comment
+	}
+}
+
+// WithLazyQuotes allows quotes to appear in unquoted fields and
+// non-doubled quotes to appear in quoted fields
+func WithLazyQuotes() Option {
+	return func(c *config) {
+		c.lazyQuotes = true
+	}
+}
+
+// WithTrimLeadingSpace ignores leading white space in fields
+func WithTrimLeadingSpace() Option {
+	return func(c *config) {
+		c.trimLeadingSpace = true
+	}
+}
+
+// WithFieldsPerRecord sets the expected number of fields per record.
+// A positive value requires every record to have exactly that many fields,
+// 0 requires every record to match the header, and a negative value (the
+// default) allows a variable number of fields.
+func WithFieldsPerRecord(n int) Option {
+	return func(c *config) {
+		c.fieldsPerRecord = n
+	}
+}
+
+// WithHeaderRow sets the zero-based record index of the header row.
+// Records before the header (such as report titles) are discarded.
+func WithHeaderRow(n int) Option {
+	return func(c *config) {
+		c.headerRow = n
+	}
+}
+
+// WithSkipRows skips the first n data rows after the header
+func WithSkipRows(n int) Option {
+	return func(c *config) {
+		c.skipRows = n
+	}
+}
+
+// WithTimeFormats replaces the list of layouts tried when parsing time.Time fields.
+// Fields with a layout= tag option are unaffected.
+func WithTimeFormats(formats ...string) Option {
+	return func(c *config) {
+		c.timeFormats = formats
+	}
+}
+
+// WithDateOrder opts in to ambiguous numeric dates such as 03/04/2024,
+// read as month-first (MonthFirst) or day-first (DayFirst)
+func WithDateOrder(order DateOrder) Option {
+	return func(c *config) {
+		c.dateOrder = order
+	}
+}
+
+// WithTimeLocation sets the location used for times without explicit timezone (default UTC).
+// Fields with a tz= tag option are unaffected.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(c *config) {
+		c.location = loc
+	}
+}
+
+// WithErrorPolicy sets how Next handles rows that fail to parse (default FailFast)
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(c *config) {
+		c.errorPolicy = policy
+	}
+}
+
+// WithRejectWriter writes the raw records of rows that fail to parse to w,
+// preceded by the header row, so they can be corrected and re-processed
+func WithRejectWriter(w io.Writer) Option {
+	return func(c *config) {
+		c.rejects = w
+	}
+}
+
+// WithAutoClose closes the source when a range loop over All or Rows ends,
+// whether by reaching the end of the data, an error, or an early break
+func WithAutoClose() Option {
+	return func(c *config) {
+		c.autoClose = true
+	}
+}
+
+// WithContext binds the iterator to ctx: URL sources issue their request with it,
+// and Next behaves like NextContext(ctx)
+func WithContext(ctx context.Context) Option {
+	return func(c *config) {
+		c.ctx = ctx
+	}
+}
+
+// WithCompression overrides compression detection for the source.
+// Use CompressionNone to read data that merely looks compressed.
+func WithCompression(compression Compression) Option {
+	return func(c *config) {
+		c.compression = compression
+	}
+}
+
+// WithEncoding decodes the source from the given character encoding to UTF-8.
+// Byte order marks are always stripped, and a UTF-16 BOM takes precedence over enc.
+func WithEncoding(enc Encoding) Option {
+	return func(c *config) {
+		c.encoding = enc
+	}
+}
+
+// WithNoHeader treats the first record as data rather than a header.
+// Fields must then be bound by position with index tags such as csv:"#3".
+// When writing, no header row is emitted.
+func WithNoHeader() Option {
+	return func(c *config) {
+		c.noHeader = true
+	}
+}
+
+// WithCaseInsensitiveHeaders matches header names to csv tags ignoring case
+func WithCaseInsensitiveHeaders() Option {
+	return WithHeaderNormalizer(strings.ToLower)
+}
+
+// WithNormalizedHeaders matches header names to csv tags ignoring case,
+// spaces, underscores, and hyphens, so that "E-mail Address",
+// "email_address", and "EMAILADDRESS" all match
+func WithNormalizedHeaders() Option {
+	return WithHeaderNormalizer(normalizeHeader)
+}
+
+// WithHeaderNormalizer applies fn to both header names and csv tag names
+// (including aliases) before matching them
+func WithHeaderNormalizer(fn func(string) string) Option {
+	return func(c *config) {
+		c.normalizeHeader = fn
+	}
+}
+
+func normalizeHeader(header string) string {
+	words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	return strings.Join(words, "")
+}
+
+// WithDuplicatePolicy sets how a field bound to a repeated header name picks
+// its column (default DuplicateError). Slice fields always collect every column.
+func WithDuplicatePolicy(policy DuplicatePolicy) Option {
+	return func(c *config) {
+		c.duplicatePolicy = policy
+	}
+}
+
+// WithStrictColumns fails construction if the header contains columns that
+// are not bound to any field, except those named in ignore
+func WithStrictColumns(ignore ...string) Option {
+	return func(c *config) {
+		c.strictColumns = true
+		c.allowedColumns = ignore
+	}
+}
+
+// WithLocale sets the locale of numeric fields without their own number
+// options, such as "de" for 1.234,56. CSVWriter formats numbers the same way,
+// except that integers are written without thousands separators.
+func WithLocale(locale string) Option {
+	return func(c *config) {
+		c.locale = locale
+	}
+}
+
+// WithBoolValues replaces the words read as true and false, matched
+// case-insensitively. CSVWriter writes the first word of each list.
+// Fields with true= or false= tag options use their own lists instead.
+func WithBoolValues(trueValues, falseValues []string) Option {
+	return func(c *config) {
+		c.trueValues = trueValues
+		c.falseValues = falseValues
+	}
+}
+
+// WithNullValues treats cells exactly matching any of values as empty, so
+// pointers stay nil and required fields fail. CSVWriter writes the first value
+// for nil pointers. Fields with a null= tag option use their own list instead.
+func WithNullValues(values ...string) Option {
+	return func(c *config) {
+		c.nullValues = values
+	}
+}
+
+// WithValidator checks every decoded row with fn, after the row's own Validate
+// method if it has one. A non-nil error rejects the row as a *ParseError
+// wrapping a *RowValidationError, handled by the error policy. T must match
+// the iterator's type parameter.
+func WithValidator[T any](fn func(*T) error) Option {
+	return func(c *config) {
+		c.rowValidator = fn
+	}
+}
@@ -0,0 +1,145 @@
+package supercsv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNew_Options(t *testing.T) {
+	csvData := `Monthly export
+generated by ERP
+name;age;email
+# ignored comment
+skipped;0;skip@example.com
+ John Doe; 30; john@example.com
+Jane "JJ" Smith;25;jane@example.com`
+
+	iterator, err := New[Person](FromReader(strings.NewReader(csvData)),
+		WithDelimiter(';'),
+		WithComment('#'),
+		WithLazyQuotes(),
+		WithTrimLeadingSpace(),
+		WithHeaderRow(2),
+		WithSkipRows(1),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	people, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read people: %v", err)
+	}
+
+	if len(people) != 2 {
+		t.Fatalf("Expected 2 people, got %d", len(people))
+	}
+	if people[0].Name != "John Doe" || people[0].Age != 30 {
+		t.Errorf("Unexpected first person: %+v", people[0])
+	}
+	if people[1].Name != `Jane "JJ" Smith` {
+		t.Errorf("Expected lazy quotes to be preserved, got '%s'", people[1].Name)
+	}
+}
+
+func TestNew_FieldsPerRecord(t *testing.T) {
+	csvData := `name,age,email
+John Doe,30,john@example.com
+Jane Smith,25`
+
+	iterator, err := New[Person](FromReader(strings.NewReader(csvData)), WithFieldsPerRecord(0))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	if _, err := iterator.Next(); err != nil {
+		t.Fatalf("Unexpected error on first row: %v", err)
+	}
+	if _, err := iterator.Next(); err == nil {
+		t.Fatal("Expected error for record with wrong number of fields")
+	}
+}
+
+func TestNew_FileNotFound(t *testing.T) {
+	_, err := New[Person](FromFile("/nonexistent/people.csv"))
+	if err == nil {
+		t.Fatal("Expected error for missing file")
+	}
+	if !strings.Contains(err.Error(), "failed to open file") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func ExampleNew() {
+	csvData := `name;age;email
+John Doe;30;john@example.com
+Jane Smith;25;jane@example.com`
+
+	iterator, err := New[Person](FromReader(strings.NewReader(csvData)),
+		WithDelimiter(';'),
+		WithSkipRows(1),
+	)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer iterator.Close()
+
+	people, _ := iterator.ToSlice()
+	for _, person := range people {
+		fmt.Printf("Name: %s, Age: %d\n", person.Name, person.Age)
+	}
+	// Output:
+	// Name: Jane Smith, Age: 25
+}
+
+type Contact struct {
+	Name  string `csv:"full_name,required,aliases=name|contact"`
+	Email string `csv:"email,required,aliases=e-mail|email_address"`
+}
+
+func TestNew_HeaderMatching(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		opts    []Option
+		wantErr string
+	}{
+		{name: "exact", header: "full_name,email"},
+		{name: "alias", header: "contact,e-mail"},
+		{name: "case insensitive", header: "Full_Name,EMAIL", opts: []Option{WithCaseInsensitiveHeaders()}},
+		{name: "case sensitive by default", header: "Full_Name,email", wantErr: "required CSV column 'full_name' (aliases: name, contact) not found"},
+		{name: "normalized", header: "Full Name,Email  Address", opts: []Option{WithNormalizedHeaders()}},
+		{name: "normalized alias", header: "NAME,E-Mail", opts: []Option{WithNormalizedHeaders()}},
+		{name: "normalized mixed separators", header: "Contact,E-mail Address", opts: []Option{WithNormalizedHeaders()}},
+		{name: "custom normalizer", header: "x_full_name,x_email", opts: []Option{WithHeaderNormalizer(func(s string) string { return strings.TrimPrefix(s, "x_") })}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvData := tt.header + "\nJohn Doe,john@example.com"
+			iterator, err := New[Contact](FromReader(strings.NewReader(csvData)), tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to create iterator: %v", err)
+			}
+			defer iterator.Close()
+
+			contact, err := iterator.Next()
+			if err != nil {
+				t.Fatalf("Failed to read contact: %v", err)
+			}
+			if contact.Name != "John Doe" || contact.Email != "john@example.com" {
+				t.Errorf("Unexpected contact: %+v", contact)
+			}
+		})
+	}
+}
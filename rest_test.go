@@ -0,0 +1,80 @@
+package supercsv
+
+import (
+	"strings"
+	"testing"
+)
+
+type CatalogItem struct {
+	SKU        string            `csv:"sku,required"`
+	Price      float64           `csv:"price"`
+	Attributes map[string]string `csv:",rest"`
+}
+
+func TestCSVIterator_RestColumns(t *testing.T) {
+	csvData := `sku,color,price,size
+A-1,red,9.99,M
+B-2,,19.99,L`
+
+	iterator, err := New[CatalogItem](FromReader(strings.NewReader(csvData)), WithStrictColumns())
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	items, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read items: %v", err)
+	}
+
+	if items[0].Price != 9.99 {
+		t.Errorf("Expected price 9.99, got %v", items[0].Price)
+	}
+	if len(items[0].Attributes) != 2 || items[0].Attributes["color"] != "red" || items[0].Attributes["size"] != "M" {
+		t.Errorf("Unexpected attributes: %v", items[0].Attributes)
+	}
+	if value, ok := items[1].Attributes["color"]; !ok || value != "" {
+		t.Errorf("Expected empty color to be kept, got %v", items[1].Attributes)
+	}
+	if len(iterator.UnmappedColumns()) != 0 {
+		t.Errorf("Expected rest field to claim all columns, got %v", iterator.UnmappedColumns())
+	}
+}
+
+func TestCSVIterator_RestDuplicateColumns(t *testing.T) {
+	csvData := `sku,x,price,x
+A-1,a,9.99,b`
+
+	_, err := New[CatalogItem](FromReader(strings.NewReader(csvData)))
+	if err == nil || !strings.Contains(err.Error(), "duplicate CSV column 'x' at positions 1, 3 for rest field Attributes") {
+		t.Fatalf("Expected duplicate rest column error, got %v", err)
+	}
+
+	iterator, err := New[CatalogItem](FromReader(strings.NewReader(csvData)), WithDuplicatePolicy(DuplicateLast))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	item, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read item: %v", err)
+	}
+	if len(item.Attributes) != 1 || item.Attributes["x"] != "b" {
+		t.Errorf("Expected the last x column, got %v", item.Attributes)
+	}
+	if unmapped := iterator.UnmappedColumns(); len(unmapped) != 1 || unmapped[0] != "x" {
+		t.Errorf("Expected the first x column to be unmapped, got %v", unmapped)
+	}
+}
+
+func TestCSVIterator_RestWrongType(t *testing.T) {
+	type BadRest struct {
+		Extra map[string]int `csv:",rest"`
+	}
+
+	_, err := NewFromReader[BadRest](strings.NewReader("a\n1"))
+	if err == nil || !strings.Contains(err.Error(), "must be map[string]string") {
+		t.Errorf("Expected rest type error, got %v", err)
+	}
+}
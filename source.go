@@ -0,0 +1,70 @@
+package supercsv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// Source describes where a CSVIterator reads its data from.
+// Use FromFile, FromURL, or FromReader to create one.
+type Source struct {
+	open func(cfg *config) (io.Reader, io.Closer, error)
+}
+
+// FromFile reads CSV data from a file path
+func FromFile(filepath string) Source {
+	return Source{open: func(cfg *config) (io.Reader, io.Closer, error) {
+		file, err := os.Open(filepath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		return decompress(file, file, cfg, CompressionAuto)
+	}}
+}
+
+// FromURL reads CSV data from a URL
+func FromURL(url string) Source {
+	return FromURLWithClient(http.DefaultClient, url)
+}
+
+// FromURLWithClient reads CSV data from a URL using a custom HTTP client.
+// The request is bound to the context set with WithContext.
+func FromURLWithClient(client *http.Client, url string) Source {
+	return Source{open: func(cfg *config) (io.Reader, io.Closer, error) {
+		ctx := cfg.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch URL: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+		}
+
+		return decompress(resp.Body, resp.Body, cfg, compressionFromEncoding(resp.Header.Get("Content-Encoding")))
+	}}
+}
+
+// FromReader reads CSV data from an io.Reader.
+// The reader is not closed by the iterator.
+//
+// All sources transparently decompress gzip, bzip2, and registered formats;
+// see WithCompression.
+func FromReader(reader io.Reader) Source {
+	return Source{open: func(cfg *config) (io.Reader, io.Closer, error) {
+		return decompress(reader, nil, cfg, CompressionAuto)
+	}}
+}
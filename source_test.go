@@ -0,0 +1,116 @@
+package supercsv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowServer serves a header and one row, then stalls until the client goes away
+func slowServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "name,age,email\nJohn Doe,30,john@example.com\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "name,age,email\nJohn Doe,30,john@example.com\n")
+	}))
+	defer server.Close()
+
+	iterator, err := NewFromURL[Person](server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	people, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read people: %v", err)
+	}
+	if len(people) != 1 || people[0].Name != "John Doe" {
+		t.Errorf("Unexpected people: %v", people)
+	}
+}
+
+func TestNewFromURL_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewFromURL[Person](server.URL)
+	if err == nil || !strings.Contains(err.Error(), "HTTP error: 404") {
+		t.Errorf("Expected HTTP 404 error, got %v", err)
+	}
+}
+
+func TestNextContext_Cancel(t *testing.T) {
+	server := slowServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	iterator, err := NewFromURLWithClient[Person](ctx, server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	if _, err := iterator.Next(); err != nil {
+		t.Fatalf("Failed to read first row: %v", err)
+	}
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = iterator.Next()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected prompt cancellation, took %v", elapsed)
+	}
+}
+
+func TestNextContext_Deadline(t *testing.T) {
+	server := slowServer(t)
+
+	iterator, err := NewFromURLWithClient[Person](context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	if _, err := iterator.Next(); err != nil {
+		t.Fatalf("Failed to read first row: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = iterator.NextContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWithContext_CancelledBeforeRequest(t *testing.T) {
+	server := slowServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New[Person](FromURL(server.URL), WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
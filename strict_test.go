@@ -0,0 +1,46 @@
+package supercsv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVIterator_UnmappedColumns(t *testing.T) {
+	csvData := `name,age,email,department,notes
+John Doe,30,john@example.com,Sales,`
+
+	iterator, err := NewFromReader[Person](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	unmapped := iterator.UnmappedColumns()
+	if strings.Join(unmapped, ",") != "department,notes" {
+		t.Errorf("Expected unmapped columns [department notes], got %v", unmapped)
+	}
+}
+
+func TestCSVIterator_StrictColumns(t *testing.T) {
+	csvData := `name,age,email,department,notes
+John Doe,30,john@example.com,Sales,`
+
+	_, err := New[Person](FromReader(strings.NewReader(csvData)), WithStrictColumns())
+	if err == nil {
+		t.Fatal("Expected error for unmapped columns")
+	}
+	if !strings.Contains(err.Error(), "CSV columns not mapped to any field: 'department', 'notes'") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	_, err = New[Person](FromReader(strings.NewReader(csvData)), WithStrictColumns("notes"))
+	if err == nil || strings.Contains(err.Error(), "'notes'") {
+		t.Errorf("Expected error for department only, got %v", err)
+	}
+
+	iterator, err := New[Person](FromReader(strings.NewReader(csvData)), WithStrictColumns("Department", "notes"), WithCaseInsensitiveHeaders())
+	if err != nil {
+		t.Fatalf("Expected allowlisted columns to be accepted, got %v", err)
+	}
+	defer iterator.Close()
+}
@@ -0,0 +1,222 @@
+package supercsv
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tagOptions holds everything parsed from a single csv struct tag
+type tagOptions struct {
+	column   string
+	index    int // Zero-based column position, or -1 to match by name
+	aliases  []string
+	required bool
+	rest     bool   // Collects all unclaimed columns into a map[string]string
+	prefix   bool   // Nested struct whose columns are named column + their own name
+	inline   bool   // Nested struct whose columns keep their own names
+	sep      string // Separator of an in-cell list for slice and array fields
+	layout   string
+	// nullValues overrides the iterator's null sentinels when non-nil
+	nullValues []string
+	// defaultValue is parsed in place of an empty cell or absent column
+	defaultValue string
+	location     *time.Location
+	unixUnit     time.Duration
+	unit         string // Unit of bare numbers in a time.Duration field, such as "ms"
+	// Number formatting; see numberFormat
+	locale         string
+	thousands      string
+	decimal        string
+	currency       bool
+	currencySymbol string
+	percent        bool
+	base           int // Integer base, 0 to detect 0x/0o/0b prefixes; 10 by default
+	// Validation options in tag order, compiled by compileRules
+	rules []tagRule
+	// Boolean vocabulary; nil keeps the iterator's
+	trueValues  []string
+	falseValues []string
+}
+
+// parseCSVTag parses a csv tag of the form "column_name[,option...]".
+// Options are either flags ("required", "unix") or key=value pairs ("layout=02.01.2006").
+// Since options are separated by commas, a comma value is written as "key=,"
+// followed by the next option. Unknown options are ignored.
+func parseCSVTag(csvTag string) (tagOptions, error) {
+	parts := strings.Split(csvTag, ",")
+	opts := tagOptions{column: strings.TrimSpace(parts[0]), index: -1, base: 10}
+
+	// "#3" binds to the fourth column by position
+	if position, ok := strings.CutPrefix(opts.column, "#"); ok {
+		index, err := parseColumnIndex(position)
+		if err != nil {
+			return opts, err
+		}
+		opts.column = ""
+		opts.index = index
+	}
+
+	for i := 1; i < len(parts); i++ {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(parts[i]), "=")
+		if hasValue && value == "" && i+1 < len(parts) && strings.TrimSpace(parts[i+1]) == "" {
+			// "thousands=," splits into "thousands=" and an empty part
+			value = ","
+			i++
+		}
+		switch key {
+		case "required":
+			opts.required = true
+		case "rest":
+			opts.rest = true
+		case "prefix":
+			opts.prefix = true
+		case "inline":
+			opts.inline = true
+		case "index":
+			index, err := parseColumnIndex(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.index = index
+		case "aliases":
+			for _, alias := range strings.Split(value, "|") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					opts.aliases = append(opts.aliases, alias)
+				}
+			}
+		case "null":
+			opts.nullValues = splitTagList(value)
+		case "sep":
+			if value == "" {
+				return opts, fmt.Errorf("sep option needs a separator")
+			}
+			opts.sep = value
+		case "default":
+			opts.defaultValue = value
+		case "locale":
+			if _, ok := lookupLocale(value); !ok {
+				return opts, fmt.Errorf("unknown locale %q", value)
+			}
+			opts.locale = value
+		case "thousands", "decimal":
+			if value == "" {
+				return opts, fmt.Errorf("%s option needs a separator", key)
+			}
+			if key == "thousands" {
+				opts.thousands = value
+			} else {
+				opts.decimal = value
+			}
+		case "currency":
+			opts.currency = true
+			opts.currencySymbol = value
+		case "pattern":
+			// The pattern takes the rest of the tag, commas included, so an
+			// option written after it would silently become part of the regex
+			for _, part := range parts[i+1:] {
+				if name, _, _ := strings.Cut(strings.TrimSpace(part), "="); tagOptionNames[name] {
+					return opts, fmt.Errorf("pattern option must come last, found %q after it", strings.TrimSpace(part))
+				}
+			}
+			value = strings.Join(append([]string{value}, parts[i+1:]...), ",")
+			i = len(parts)
+			opts.rules = append(opts.rules, tagRule{name: key, param: value})
+		case "min", "max", "len", "maxlen", "oneof":
+			opts.rules = append(opts.rules, tagRule{name: key, param: value})
+		case "base":
+			base, err := strconv.Atoi(value)
+			if err != nil || base < 0 || base == 1 || base > 36 {
+				return opts, fmt.Errorf("invalid base option %q", value)
+			}
+			opts.base = base
+		case "percent":
+			opts.percent = true
+		case "true":
+			opts.trueValues = splitTagList(value)
+		case "false":
+			opts.falseValues = splitTagList(value)
+		case "layout":
+			opts.layout = value
+		case "tz":
+			loc, err := time.LoadLocation(value)
+			if err != nil {
+				return opts, fmt.Errorf("invalid tz option %q: %w", value, err)
+			}
+			opts.location = loc
+		case "unit":
+			if !slices.Contains(durationUnits, value) {
+				return opts, fmt.Errorf("invalid unit option %q (expected one of %s)", value, strings.Join(durationUnits, ", "))
+			}
+			opts.unit = value
+		case "unix":
+			opts.unixUnit = time.Second
+		case "unixms":
+			opts.unixUnit = time.Millisecond
+		case "unixnano":
+			opts.unixUnit = time.Nanosecond
+		}
+	}
+
+	return opts, nil
+}
+
+// tagOptionNames lists every option parseCSVTag understands
+var tagOptionNames = map[string]bool{
+	"required": true, "rest": true, "prefix": true, "inline": true, "index": true,
+	"aliases": true, "null": true, "sep": true, "default": true,
+	"locale": true, "thousands": true, "decimal": true, "currency": true, "percent": true, "base": true,
+	"pattern": true, "min": true, "max": true, "len": true, "maxlen": true, "oneof": true,
+	"true": true, "false": true,
+	"layout": true, "tz": true, "unit": true, "unix": true, "unixms": true, "unixnano": true,
+}
+
+// splitTagList splits a "a|b|c" option value, dropping empty entries
+func splitTagList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func parseColumnIndex(value string) (int, error) {
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid column index %q", value)
+	}
+	return index, nil
+}
+
+var restMapType = reflect.TypeOf(map[string]string(nil))
+
+// checkRestField validates a field tagged with the rest option
+func checkRestField(field structField, seen bool) error {
+	if field.typ != restMapType {
+		return fmt.Errorf("field %s tagged rest must be map[string]string, got %s", field.name, field.typ)
+	}
+	if seen {
+		return fmt.Errorf("field %s: only one field may be tagged rest", field.name)
+	}
+	return nil
+}
+
+// checkDefault parses a field's default value once so that a bad default is
+// reported when the iterator is created rather than on the first empty cell
+func checkDefault(field *fieldInfo) error {
+	if field.defaultValue == "" {
+		return nil
+	}
+	if field.rest || isRepeatedField(field.fieldType) && field.sep == "" {
+		return fmt.Errorf("field %s: default is not supported on fields reading several columns", field.name)
+	}
+	if err := setFieldValue(reflect.New(field.fieldType).Elem(), field.defaultValue, field.fieldType, field); err != nil {
+		return fmt.Errorf("field %s: invalid default %q: %w", field.name, field.defaultValue, err)
+	}
+	return nil
+}
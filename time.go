@@ -0,0 +1,103 @@
+package supercsv
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DateOrder selects which ambiguous numeric date formats are tried when parsing time.Time fields
+type DateOrder int
+
+const (
+	// DateOrderNone tries no ambiguous formats (the default)
+	DateOrderNone DateOrder = iota
+	// MonthFirst tries US formats such as 01/02/2006
+	MonthFirst
+	// DayFirst tries European formats such as 02/01/2006
+	DayFirst
+)
+
+// defaultTimeFormats are unambiguous and tried in order of preference
+var defaultTimeFormats = []string{
+	time.RFC3339,          // "2006-01-02T15:04:05Z07:00"
+	time.RFC3339Nano,      // "2006-01-02T15:04:05.999999999Z07:00"
+	"2006-01-02 15:04:05", // Common SQL datetime format
+	"2006-01-02",          // Date only
+	"15:04:05",            // Time only
+}
+
+var monthFirstTimeFormats = []string{
+	"01/02/2006",          // US date format
+	"01/02/2006 15:04:05", // US datetime format
+}
+
+var dayFirstTimeFormats = []string{
+	"02/01/2006",          // European date format
+	"02/01/2006 15:04:05", // European datetime format
+}
+
+// timeFormats returns the auto-detection format list for the given date order
+func timeFormats(order DateOrder) []string {
+	formats := append([]string(nil), defaultTimeFormats...)
+	switch order {
+	case MonthFirst:
+		formats = append(formats, monthFirstTimeFormats...)
+	case DayFirst:
+		formats = append(formats, dayFirstTimeFormats...)
+	}
+	return formats
+}
+
+// parseTime parses a time cell using the field's epoch unit or its layouts.
+// Layouts without explicit timezone are interpreted in the field's location.
+func parseTime(strValue string, field *fieldInfo) (time.Time, error) {
+	if field.unixUnit != 0 {
+		epoch, err := strconv.ParseInt(strValue, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid unix timestamp: %w", err)
+		}
+		var timeVal time.Time
+		switch field.unixUnit {
+		case time.Second:
+			timeVal = time.Unix(epoch, 0)
+		case time.Millisecond:
+			timeVal = time.UnixMilli(epoch)
+		default:
+			timeVal = time.Unix(0, epoch)
+		}
+		return timeVal.In(field.location), nil
+	}
+
+	for _, format := range field.timeFormats {
+		timeVal, err := time.ParseInLocation(format, strValue, field.location)
+		if err == nil {
+			return timeVal, nil
+		}
+	}
+
+	if len(field.timeFormats) == 1 {
+		return time.Time{}, fmt.Errorf("invalid time format: %s (expected layout %s)", strValue, field.timeFormats[0])
+	}
+	return time.Time{}, fmt.Errorf("invalid time format: %s (supported formats: RFC3339, YYYY-MM-DD, YYYY-MM-DD HH:MM:SS, etc.)", strValue)
+}
+
+// formatTime formats a time cell using the field's epoch unit, layout, or RFC3339
+func formatTime(timeVal time.Time, field *fieldInfo) string {
+	switch field.unixUnit {
+	case time.Second:
+		return strconv.FormatInt(timeVal.Unix(), 10)
+	case time.Millisecond:
+		return strconv.FormatInt(timeVal.UnixMilli(), 10)
+	case time.Nanosecond:
+		return strconv.FormatInt(timeVal.UnixNano(), 10)
+	}
+
+	if field.location != nil {
+		timeVal = timeVal.In(field.location)
+	}
+	if field.layout != "" {
+		return timeVal.Format(field.layout)
+	}
+	return timeVal.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,176 @@
+package supercsv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Shipment struct {
+	ID        int        `csv:"id,required"`
+	Created   time.Time  `csv:"created,layout=02.01.2006"`
+	Delivered *time.Time `csv:"delivered,layout=2006-01-02 15:04,tz=Europe/Berlin"`
+	Scanned   time.Time  `csv:"scanned,unix"`
+	Logged    time.Time  `csv:"logged,unixms"`
+}
+
+func TestCSVIterator_TimeTagOptions(t *testing.T) {
+	csvData := `id,created,delivered,scanned,logged
+1,03.04.2024,2024-04-05 14:30,1712300000,1712300000123`
+
+	iterator, err := NewFromReader[Shipment](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	shipment, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read shipment: %v", err)
+	}
+
+	expectedCreated := time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)
+	if !shipment.Created.Equal(expectedCreated) {
+		t.Errorf("Expected created %v, got %v", expectedCreated, shipment.Created)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	expectedDelivered := time.Date(2024, 4, 5, 14, 30, 0, 0, berlin)
+	if shipment.Delivered == nil || !shipment.Delivered.Equal(expectedDelivered) {
+		t.Errorf("Expected delivered %v, got %v", expectedDelivered, shipment.Delivered)
+	}
+
+	if shipment.Scanned.Unix() != 1712300000 || shipment.Scanned.Location() != time.UTC {
+		t.Errorf("Expected scanned at unix 1712300000 UTC, got %v", shipment.Scanned)
+	}
+	if shipment.Logged.UnixMilli() != 1712300000123 {
+		t.Errorf("Expected logged at unix ms 1712300000123, got %v", shipment.Logged)
+	}
+}
+
+func TestCSVIterator_TimeLayoutMismatch(t *testing.T) {
+	csvData := `id,created
+1,2024-04-03`
+
+	iterator, err := NewFromReader[Shipment](strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	_, err = iterator.Next()
+	if err == nil {
+		t.Fatal("Expected error for value not matching layout")
+	}
+	if !strings.Contains(err.Error(), "expected layout 02.01.2006") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestCSVIterator_InvalidTimezone(t *testing.T) {
+	type BadZone struct {
+		At time.Time `csv:"at,tz=Mars/Olympus_Mons"`
+	}
+
+	_, err := NewFromReader[BadZone](strings.NewReader("at\n"))
+	if err == nil {
+		t.Fatal("Expected error for invalid tz option")
+	}
+	if !strings.Contains(err.Error(), "invalid tz option") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestCSVIterator_DateOrder(t *testing.T) {
+	csvData := `event_name,start_date
+Meeting,03/04/2024`
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected time.Time
+		wantErr  bool
+	}{
+		{name: "default rejects ambiguous dates", wantErr: true},
+		{name: "month first", opts: []Option{WithDateOrder(MonthFirst)}, expected: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{name: "day first", opts: []Option{WithDateOrder(DayFirst)}, expected: time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{name: "custom formats", opts: []Option{WithTimeFormats("01/02/2006")}, expected: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator, err := New[Event](FromReader(strings.NewReader(csvData)), tt.opts...)
+			if err != nil {
+				t.Fatalf("Failed to create iterator: %v", err)
+			}
+			defer iterator.Close()
+
+			event, err := iterator.Next()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error for ambiguous date")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to read event: %v", err)
+			}
+			if !event.StartDate.Equal(tt.expected) {
+				t.Errorf("Expected start date %v, got %v", tt.expected, event.StartDate)
+			}
+		})
+	}
+}
+
+func TestCSVIterator_TimeLocation(t *testing.T) {
+	csvData := `event_name,start_date
+Meeting,2024-03-04 09:00:00`
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+
+	iterator, err := New[Event](FromReader(strings.NewReader(csvData)), WithTimeLocation(tokyo))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	event, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read event: %v", err)
+	}
+
+	expected := time.Date(2024, 3, 4, 9, 0, 0, 0, tokyo)
+	if !event.StartDate.Equal(expected) {
+		t.Errorf("Expected start date %v, got %v", expected, event.StartDate)
+	}
+}
+
+func TestCSVWriter_TimeTagOptions(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	delivered := time.Date(2024, 4, 5, 12, 30, 0, 0, time.UTC)
+	shipment := &Shipment{
+		ID:        1,
+		Created:   time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+		Delivered: &delivered,
+		Scanned:   time.Unix(1712300000, 0),
+		Logged:    time.UnixMilli(1712300000123).In(berlin),
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Shipment](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll([]*Shipment{shipment}); err != nil {
+		t.Fatalf("Failed to write shipment: %v", err)
+	}
+
+	expected := "id,created,delivered,scanned,logged\n1,03.04.2024,2024-04-05 14:30,1712300000,1712300000123\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
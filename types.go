@@ -0,0 +1,76 @@
+package supercsv
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// durationUnits are the units accepted by the unit= tag option, as spelled
+// by time.ParseDuration
+var durationUnits = []string{"ns", "us", "µs", "μs", "ms", "s", "m", "h"}
+
+// parseDuration reads a time.Duration such as "1h30m". Bare numbers are read
+// in the field's unit, if it has one.
+func parseDuration(value string, field *fieldInfo) (time.Duration, error) {
+	if field.unit != "" {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			value += field.unit
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %w", err)
+	}
+	return d, nil
+}
+
+// formatDuration writes d as a bare number in the field's unit, or in
+// time.Duration's own notation otherwise
+func formatDuration(d time.Duration, field *fieldInfo) string {
+	if field.unit == "" {
+		return d.String()
+	}
+	unit, _ := time.ParseDuration("1" + field.unit)
+	if d%unit == 0 {
+		return strconv.FormatInt(int64(d/unit), 10)
+	}
+	return strconv.FormatFloat(float64(d)/float64(unit), 'f', -1, 64)
+}
+
+// checkUnit validates a unit= tag option, which must be a time.Duration unit
+// such as "ms" on a duration field
+func checkUnit(name string, fieldType reflect.Type, tag tagOptions) error {
+	if tag.unit == "" {
+		return nil
+	}
+	for fieldType.Kind() == reflect.Ptr || isRepeatedField(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType != durationType {
+		return fmt.Errorf("field %s tagged unit must be a time.Duration, got %s", name, fieldType)
+	}
+	return nil
+}
+
+// setBigFloat parses value with enough precision to keep every digit, where
+// big.Float's UnmarshalText would round to 64 bits
+func setBigFloat(fieldValue reflect.Value, value string) error {
+	prec := max(64, uint(math.Ceil(float64(len(value))*math.Log2(10))))
+
+	f := fieldValue.Addr().Interface().(*big.Float)
+	f.SetPrec(prec)
+	if _, _, err := f.Parse(value, 0); err != nil {
+		return fmt.Errorf("invalid float: %w", err)
+	}
+	return nil
+}
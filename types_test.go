@@ -0,0 +1,119 @@
+package supercsv
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Invoice struct {
+	Total   *big.Float     `csv:"total"`
+	Units   *big.Int       `csv:"units"`
+	Share   big.Rat        `csv:"share"`
+	Elapsed time.Duration  `csv:"elapsed"`
+	Latency time.Duration  `csv:"latency,unit=ms"`
+	Timeout *time.Duration `csv:"timeout"`
+}
+
+const invoiceCSV = `total,units,share,elapsed,latency,timeout
+12345678901234567890.123456789,123456789012345678901234567890,1/3,1h30m,1500,
+0.1,-7,0.25,250ms,2.5,5s`
+
+func TestDurationAndBigTypes(t *testing.T) {
+	iterator, err := NewFromReader[Invoice](strings.NewReader(invoiceCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	invoices, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read invoices: %v", err)
+	}
+
+	first := invoices[0]
+	if got := first.Total.Text('f', 9); got != "12345678901234567890.123456789" {
+		t.Errorf("Expected total to keep every digit, got %s", got)
+	}
+	if first.Units.String() != "123456789012345678901234567890" {
+		t.Errorf("Unexpected units: %s", first.Units)
+	}
+	if first.Share.String() != "1/3" {
+		t.Errorf("Unexpected share: %s", first.Share.String())
+	}
+	if first.Elapsed != 90*time.Minute || first.Latency != 1500*time.Millisecond || first.Timeout != nil {
+		t.Errorf("Unexpected durations: %v, %v, %v", first.Elapsed, first.Latency, first.Timeout)
+	}
+
+	second := invoices[1]
+	if second.Latency != 2500*time.Microsecond || second.Timeout == nil || *second.Timeout != 5*time.Second {
+		t.Errorf("Unexpected durations: %v, %v", second.Latency, second.Timeout)
+	}
+}
+
+func TestDurationErrors(t *testing.T) {
+	iterator, err := NewFromReader[Invoice](strings.NewReader("elapsed\n90"))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+	if _, err := iterator.Next(); err == nil || !strings.Contains(err.Error(), "invalid duration") {
+		t.Errorf("Expected bare number without unit to fail, got %v", err)
+	}
+
+	type BadUnit struct {
+		Count int `csv:"count,unit=ms"`
+	}
+	if _, err := NewFromReader[BadUnit](strings.NewReader("count\n1")); err == nil || !strings.Contains(err.Error(), "must be a time.Duration") {
+		t.Errorf("Expected unit error, got %v", err)
+	}
+
+	type ScaledUnit struct {
+		Elapsed time.Duration `csv:"elapsed,unit=5m"`
+	}
+	if _, err := NewFromReader[ScaledUnit](strings.NewReader("elapsed\n2")); err == nil || !strings.Contains(err.Error(), `invalid unit option "5m"`) {
+		t.Errorf("Expected unit=5m to be rejected, got %v", err)
+	}
+	for _, unit := range []string{"1h30m", "1", "days", ""} {
+		if _, err := parseCSVTag("elapsed,unit=" + unit); err == nil {
+			t.Errorf("Expected unit=%s to be rejected", unit)
+		}
+	}
+	for _, unit := range durationUnits {
+		if _, err := parseCSVTag("elapsed,unit=" + unit); err != nil {
+			t.Errorf("Expected unit=%s to be accepted, got %v", unit, err)
+		}
+	}
+}
+
+func TestWriterDurationAndBigTypes(t *testing.T) {
+	iterator, err := NewFromReader[Invoice](strings.NewReader(invoiceCSV))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	invoices, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read invoices: %v", err)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterToWriter[Invoice](&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.WriteAll(invoices); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+
+	expected := `total,units,share,elapsed,latency,timeout
+12345678901234567890.123456789,123456789012345678901234567890,1/3,1h30m0s,1500,
+0.1,-7,1/4,250ms,2.5,5s
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
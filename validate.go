@@ -0,0 +1,203 @@
+package supercsv
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationError reports a value that failed one of its field's validation
+// tag options. It is wrapped in a *ParseError naming the row and field.
+type ValidationError struct {
+	Rule  string // Tag option that failed, such as "max" or "pattern"
+	Param string // Option value, such as "150"
+	Value string // Cell text that was checked
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("value %q fails %s=%s", e.Value, e.Rule, e.Param)
+}
+
+// tagRule is a validation option as written in the tag
+type tagRule struct {
+	name  string
+	param string
+}
+
+// validator is a compiled validation rule. Text rules check the cell text,
+// value rules check the decoded field value.
+type validator struct {
+	rule       tagRule
+	checkText  func(string) bool
+	checkValue func(reflect.Value) bool
+}
+
+// compileRules turns a field's validation options into validators, checking
+// each parameter against the field type
+func compileRules(name string, fieldType reflect.Type, rules []tagRule) ([]validator, error) {
+	var validators []validator
+	for _, rule := range rules {
+		v := validator{rule: rule}
+		var err error
+		switch rule.name {
+		case "pattern":
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(rule.param); err == nil {
+				v.checkText = re.MatchString
+			}
+		case "oneof":
+			allowed := splitTagList(rule.param)
+			v.checkText = func(text string) bool { return slices.Contains(allowed, text) }
+		case "min", "max":
+			v.checkValue, err = compileBound(rule, fieldType)
+		case "len", "maxlen":
+			v.checkValue, err = compileLength(rule, fieldType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: invalid %s=%s: %w", name, rule.name, rule.param, err)
+		}
+		validators = append(validators, v)
+	}
+	return validators, nil
+}
+
+// compileBound compiles min and max. Numbers and durations compare their
+// value; strings, slices, and arrays compare their length.
+func compileBound(rule tagRule, fieldType reflect.Type) (func(reflect.Value) bool, error) {
+	fieldType = indirectType(fieldType)
+	inRange := func(c int) bool { return c >= 0 }
+	if rule.name == "max" {
+		inRange = func(c int) bool { return c <= 0 }
+	}
+
+	if fieldType == durationType {
+		bound, err := time.ParseDuration(rule.param)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool { return inRange(compare(v.Int(), int64(bound))) }, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bound, err := strconv.ParseInt(rule.param, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool { return inRange(compare(v.Int(), bound)) }, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bound, err := strconv.ParseUint(rule.param, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool { return inRange(compare(v.Uint(), bound)) }, nil
+	case reflect.Float32, reflect.Float64:
+		bound, err := strconv.ParseFloat(rule.param, 64)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool { return inRange(compare(v.Float(), bound)) }, nil
+	}
+
+	return compileLength(rule, fieldType)
+}
+
+// compileLength compiles a length rule for strings (counted in characters),
+// slices, and arrays
+func compileLength(rule tagRule, fieldType reflect.Type) (func(reflect.Value) bool, error) {
+	fieldType = indirectType(fieldType)
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array:
+	default:
+		return nil, fmt.Errorf("not supported for %s", fieldType)
+	}
+
+	bound, err := strconv.Atoi(rule.param)
+	if err != nil || bound < 0 {
+		return nil, fmt.Errorf("length must be a non-negative integer")
+	}
+
+	return func(v reflect.Value) bool {
+		length := v.Len()
+		if v.Kind() == reflect.String {
+			length = utf8.RuneCountInString(v.String())
+		}
+		switch rule.name {
+		case "min":
+			return length >= bound
+		case "max", "maxlen":
+			return length <= bound
+		}
+		return length == bound
+	}, nil
+}
+
+func compare[N int64 | uint64 | float64](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// validateText runs the field's text rules against a cell
+func (f *fieldInfo) validateText(text string) error {
+	for _, v := range f.validators {
+		if v.checkText != nil && !v.checkText(text) {
+			return &ValidationError{Rule: v.rule.name, Param: v.rule.param, Value: text}
+		}
+	}
+	return nil
+}
+
+// validateCell runs the field's text rules against a cell, or against each
+// element of an in-cell list so that sep= lists behave like repeated columns
+func (f *fieldInfo) validateCell(cell string) error {
+	if f.sep == "" {
+		return f.validateText(cell)
+	}
+	for _, part := range strings.Split(cell, f.sep) {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		if err := f.validateText(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue runs the field's value rules against its decoded value.
+// Nil pointers are not checked.
+func (f *fieldInfo) validateValue(value reflect.Value, text string) error {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	for _, v := range f.validators {
+		if v.checkValue != nil && !v.checkValue(value) {
+			return &ValidationError{Rule: v.rule.name, Param: v.rule.param, Value: text}
+		}
+	}
+	return nil
+}
+
+// cellsText joins the cells read by a repeated field, for error reports
+func cellsText(record []string, columnIndexes []int) string {
+	var cells []string
+	for _, columnIndex := range columnIndexes {
+		if columnIndex < len(record) {
+			cells = append(cells, strings.TrimSpace(record[columnIndex]))
+		}
+	}
+	return strings.Join(cells, ",")
+}
@@ -0,0 +1,275 @@
+package supercsv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Member struct {
+	Name    string   `csv:"name,maxlen=8"`
+	Age     *int     `csv:"age,min=0,max=150"`
+	Country string   `csv:"country,len=2"`
+	Status  string   `csv:"status,oneof=active|inactive"`
+	Tags    []string `csv:"tag,max=2"`
+	Code    string   `csv:"code,pattern=^[A-Z]{2,3}-[0-9]+$"`
+}
+
+func TestValidationRules(t *testing.T) {
+	csvData := `name,age,country,status,tag,tag,tag,code
+Ann,30,DE,active,a,,,AB-1
+Bartholomew,30,DE,active,,,,AB-1
+Cy,151,DE,active,,,,AB-1
+Di,-1,DE,active,,,,AB-1
+Ed,,DEU,active,,,,AB-1
+Flo,,FR,banned,,,,AB-1
+Gus,,FR,inactive,a,b,c,AB-1
+Hal,,FR,inactive,,,,ab-1
+Ivy,,FR,inactive,x,y,,ABCD-12`
+
+	iterator, err := New[Member](FromReader(strings.NewReader(csvData)), WithErrorPolicy(SkipAndCollect))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	members, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read members: %v", err)
+	}
+	if len(members) != 1 || members[0].Name != "Ann" {
+		t.Fatalf("Expected only Ann to pass, got %d members", len(members))
+	}
+
+	expected := []struct {
+		field, rule, value string
+	}{
+		{"Name", "maxlen", "Bartholomew"},
+		{"Age", "max", "151"},
+		{"Age", "min", "-1"},
+		{"Country", "len", "DEU"},
+		{"Status", "oneof", "banned"},
+		{"Tags", "max", "a,b,c"},
+		{"Code", "pattern", "ab-1"},
+		{"Code", "pattern", "ABCD-12"},
+	}
+
+	errs := iterator.Errors()
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, perr := range errs {
+		var verr *ValidationError
+		if !errors.As(perr, &verr) {
+			t.Errorf("Error %d: expected *ValidationError, got %v", i, perr)
+			continue
+		}
+		if perr.Field != expected[i].field || verr.Rule != expected[i].rule || verr.Value != expected[i].value {
+			t.Errorf("Error %d: expected %s %s on %q, got %s %s on %q", i,
+				expected[i].field, expected[i].rule, expected[i].value, perr.Field, verr.Rule, verr.Value)
+		}
+	}
+}
+
+func TestValidationRulesOnSepLists(t *testing.T) {
+	type Quote struct {
+		Tags  []string `csv:"tags,sep=|,oneof=a|b"`
+		Codes []string `csv:"codes,sep=;,pattern=^[A-Z]{3}$"`
+	}
+
+	csvData := `tags,codes
+a|b,USD;EUR
+a|c,USD
+a,USD;eur`
+
+	iterator, err := New[Quote](FromReader(strings.NewReader(csvData)), WithErrorPolicy(SkipAndCollect))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	quotes, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read quotes: %v", err)
+	}
+	if len(quotes) != 1 || len(quotes[0].Tags) != 2 || len(quotes[0].Codes) != 2 {
+		t.Fatalf("Expected only the first row to pass, got %+v", quotes)
+	}
+
+	errs := iterator.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	for i, want := range []struct{ rule, value string }{{"oneof", "c"}, {"pattern", "eur"}} {
+		var verr *ValidationError
+		if !errors.As(errs[i], &verr) || verr.Rule != want.rule || verr.Value != want.value {
+			t.Errorf("Error %d: expected %s on %q, got %v", i, want.rule, want.value, errs[i])
+		}
+	}
+}
+
+func TestInvalidValidationRules(t *testing.T) {
+	type BadPattern struct {
+		Code string `csv:"code,pattern=[a-"`
+	}
+	type BadBound struct {
+		Age int `csv:"age,min=old"`
+	}
+	type OptionAfterPattern struct {
+		Code string `csv:"code,pattern=^[A-Z]{3}$,required"`
+	}
+	type LenOnNumber struct {
+		Score float64 `csv:"score,len=2"`
+	}
+
+	tests := []struct {
+		name string
+		new  func() error
+		want string
+	}{
+		{"bad pattern", func() error {
+			_, err := NewFromReader[BadPattern](strings.NewReader("code\nx"))
+			return err
+		}, "invalid pattern=[a-"},
+		{"bad bound", func() error {
+			_, err := NewFromReader[BadBound](strings.NewReader("age\n1"))
+			return err
+		}, "invalid min=old"},
+		{"option after pattern", func() error {
+			_, err := NewFromReader[OptionAfterPattern](strings.NewReader("code\nABC"))
+			return err
+		}, `pattern option must come last, found "required" after it`},
+		{"len on number", func() error {
+			_, err := NewFromReader[LenOnNumber](strings.NewReader("score\n1"))
+			return err
+		}, "invalid len=2: not supported for float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.new(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+type Booking struct {
+	Guest string    `csv:"guest"`
+	Start time.Time `csv:"start"`
+	End   time.Time `csv:"end"`
+}
+
+func (b *Booking) Validate() error {
+	if !b.End.After(b.Start) {
+		return errEndBeforeStart
+	}
+	return nil
+}
+
+var errEndBeforeStart = errors.New("end must be after start")
+
+func TestRowValidateMethod(t *testing.T) {
+	csvData := `guest,start,end
+Ann,2024-03-01,2024-03-05
+Bob,2024-03-05,2024-03-01
+Cy,2024-04-01,2024-04-02`
+
+	var rejects strings.Builder
+	iterator, err := New[Booking](FromReader(strings.NewReader(csvData)),
+		WithErrorPolicy(SkipAndCollect), WithRejectWriter(&rejects))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	bookings, err := iterator.ToSlice()
+	if err != nil {
+		t.Fatalf("Failed to read bookings: %v", err)
+	}
+	if len(bookings) != 2 || bookings[0].Guest != "Ann" || bookings[1].Guest != "Cy" {
+		t.Fatalf("Expected Ann and Cy, got %+v", bookings)
+	}
+
+	errs := iterator.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(errs), errs)
+	}
+	var rowErr *RowValidationError
+	if !errors.As(errs[0], &rowErr) || !errors.Is(errs[0], errEndBeforeStart) {
+		t.Errorf("Expected *RowValidationError wrapping errEndBeforeStart, got %v", errs[0])
+	}
+	if errs[0].Row != 2 || errs[0].Line != 3 {
+		t.Errorf("Expected row 2 on line 3, got row %d on line %d", errs[0].Row, errs[0].Line)
+	}
+	if !strings.Contains(rejects.String(), "Bob,2024-03-05,2024-03-01") {
+		t.Errorf("Expected Bob's row to be rejected, got %q", rejects.String())
+	}
+}
+
+func TestRowValidatePointerType(t *testing.T) {
+	csvData := `guest,start,end
+Ann,2024-03-01,2024-03-05
+Bob,2024-03-05,2024-03-01`
+
+	iterator, err := New[*Booking](FromReader(strings.NewReader(csvData)))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	booking, err := iterator.Next()
+	if err != nil {
+		t.Fatalf("Failed to read first booking: %v", err)
+	}
+	if (*booking).Guest != "Ann" || (*booking).End.Day() != 5 {
+		t.Errorf("Unexpected booking: %+v", *booking)
+	}
+	if _, err := iterator.Next(); !errors.Is(err, errEndBeforeStart) {
+		t.Errorf("Expected Validate on *Booking to reject Bob, got %v", err)
+	}
+}
+
+type OrderLine struct {
+	Qty   int     `csv:"qty"`
+	Price float64 `csv:"price"`
+	Total float64 `csv:"total"`
+}
+
+func TestWithValidator(t *testing.T) {
+	csvData := `qty,price,total
+2,1.5,3
+3,2,7`
+
+	checkTotal := func(line *OrderLine) error {
+		if float64(line.Qty)*line.Price != line.Total {
+			return fmt.Errorf("total %v is not qty × price", line.Total)
+		}
+		return nil
+	}
+
+	iterator, err := New[OrderLine](FromReader(strings.NewReader(csvData)), WithValidator(checkTotal))
+	if err != nil {
+		t.Fatalf("Failed to create iterator: %v", err)
+	}
+	defer iterator.Close()
+
+	if _, err := iterator.Next(); err != nil {
+		t.Fatalf("Failed to read first line: %v", err)
+	}
+	_, err = iterator.Next()
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Row != 2 || !strings.Contains(err.Error(), "total 7 is not qty × price") {
+		t.Fatalf("Expected row 2 to fail validation, got %v", err)
+	}
+}
+
+func TestWithValidatorWrongType(t *testing.T) {
+	_, err := New[OrderLine](FromReader(strings.NewReader("qty\n1")),
+		WithValidator(func(*Booking) error { return nil }))
+	if err == nil || !strings.Contains(err.Error(), "want func(*supercsv.OrderLine) error") {
+		t.Errorf("Expected a type mismatch error, got %v", err)
+	}
+}